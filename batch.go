@@ -0,0 +1,103 @@
+package bittrex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+//Operations accepted in one POST /batch request
+const MaxBatchOperations = 25
+
+//Queues order operations to be submitted together in one signed POST /batch request.
+//A batch is executed at most once, queue the next operations on a new batch.
+type Batch struct {
+	api        *BittrexAPI
+	operations []batchOperation
+	executed   bool
+}
+
+type batchOperation struct {
+	Resource  string      `json:"resource"`
+	Operation string      `json:"operation"`
+	Payload   interface{} `json:"payload"`
+}
+
+//Result of a single batch operation, in the order the operations were queued
+type BatchResult struct {
+	Status int    //HTTP status of the operation
+	Order  *Order //Order.Code is set when the operation failed
+}
+
+func (this BatchResult) Succeeded() bool {
+	return this.Status >= 200 && this.Status < 300
+}
+
+//*APIError for a failed operation, nil otherwise
+func (this BatchResult) Err() error {
+	return bulkResultError(this.Status, this.Order)
+}
+
+func (this *BittrexAPI) NewBatch() *Batch {
+	return &Batch{api: this}
+}
+
+//Required marketSymbol, direction, type, timeInForce
+func (this *Batch) CreateOrder(order Order) *Batch {
+	this.operations = append(this.operations, batchOperation{Resource: "ORDER", Operation: "POST", Payload: order})
+	return this
+}
+
+func (this *Batch) CancelOrder(orderID string) *Batch {
+	this.operations = append(this.operations, batchOperation{Resource: "ORDER", Operation: "DELETE", Payload: OrderCancel{ID: orderID}})
+	return this
+}
+
+func (this *Batch) Len() int {
+	return len(this.operations)
+}
+
+func (this *Batch) Execute() ([]BatchResult, error) {
+	return this.ExecuteCtx(context.Background())
+}
+
+func (this *Batch) ExecuteCtx(ctx context.Context) ([]BatchResult, error) {
+	if this.executed {
+		return nil, errors.New("batch has already been executed")
+	}
+	if len(this.operations) == 0 {
+		return nil, errors.New("batch has no operations")
+	}
+	if len(this.operations) > MaxBatchOperations {
+		return nil, errors.New("batch has " + strconv.Itoa(len(this.operations)) + " operations, at most " + strconv.Itoa(MaxBatchOperations) + " are accepted")
+	}
+
+	payload, err := json.Marshal(this.operations)
+	if err != nil {
+		return nil, err
+	}
+
+	//Marked before sending, a failed request may still have placed some of the orders
+	this.executed = true
+	uri := this.api.uri + "/batch"
+	body, err := this.api.client.Do(ctx, "POST", uri, string(payload), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []struct {
+		Status  int    `json:"status"`
+		Payload *Order `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return nil, errors.New(err.Error() + string(body))
+	}
+
+	results := make([]BatchResult, 0, len(responses))
+	for _, response := range responses {
+		results = append(results, BatchResult{Status: response.Status, Order: response.Payload})
+	}
+
+	return results, nil
+}
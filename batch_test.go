@@ -0,0 +1,86 @@
+package bittrex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestBatchFixture(t *testing.T) {
+	gunit.Run(new(BatchFixture), t)
+}
+
+type BatchFixture struct {
+	*gunit.Fixture
+}
+
+func (this *BatchFixture) Setup() {}
+
+func (this *BatchFixture) TestExecute() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	quantity := decimal.NewFromFloat(5)
+	limit := decimal.NewFromFloat(0.00039561)
+	batch := bittrex.NewBatch().
+		CreateOrder(Order{
+			MarketSymbol: "ETH-BTC",
+			Direction:    OrderSideBuy,
+			OrderType:    OrderTypeLimit,
+			TimeInForce:  TimeInForceGTC,
+			Quantity:     &quantity,
+			Limit:        &limit,
+		}).
+		CancelOrder("55eb2c82-4184-4a24-8b6e-ee154b2f7eaf")
+	this.So(batch.Len(), should.Equal, 2)
+
+	result, err := batch.Execute()
+	orderNotOpen := "ORDER_NOT_OPEN"
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 2)
+	this.So(result[0].Succeeded(), should.BeTrue)
+	this.So(result[0].Status, should.Equal, 201)
+	this.So(result[0].Order.OrderID, should.Equal, "fab677a0-510e-456e-b450-8a75cea69f5d")
+	this.So(result[0].Order.Status, should.Equal, "OPEN")
+	this.So(result[0].Err(), should.BeNil)
+	this.So(result[1].Succeeded(), should.BeFalse)
+	this.So(errors.Is(result[1].Err(), ErrOrderNotOpen), should.BeTrue)
+	this.So(result[1], should.Resemble, BatchResult{Status: 409, Order: &Order{Code: &orderNotOpen}})
+	this.So(BatchResult{Status: 503}.Err(), should.Resemble, CancelResult{StatusCode: "503"}.Err())
+}
+
+func (this *BatchFixture) TestExecuteEmpty() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.NewBatch().Execute()
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+}
+
+func (this *BatchFixture) TestExecuteTwice() {
+	client := &recordingClient{fallback: &fakeBittrexClient{}}
+	bittrex := NewBittrexAPI(client, "")
+	batch := bittrex.NewBatch().CancelOrder("55eb2c82-4184-4a24-8b6e-ee154b2f7eaf")
+	batch.Execute()
+
+	result, err := batch.Execute()
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+	this.So(len(client.requests), should.Equal, 1)
+}
+
+func (this *BatchFixture) TestExecuteTooManyOperations() {
+	client := &recordingClient{fallback: &fakeBittrexClient{}}
+	bittrex := NewBittrexAPI(client, "")
+	batch := bittrex.NewBatch()
+	for i := 0; i <= MaxBatchOperations; i++ {
+		batch.CancelOrder("55eb2c82-4184-4a24-8b6e-ee154b2f7eaf")
+	}
+
+	result, err := batch.Execute()
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+	this.So(client.requests, should.BeEmpty)
+}
@@ -1,21 +1,35 @@
 package bittrex
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/shopspring/decimal"
 )
 
 type BittrexAPI struct {
-	uri    string
-	client Client
+	uri     string
+	client  Client
+	markets *marketRules
+	now     func() time.Time
 }
 
 type OrderSide string
 type OrderType string
 type TimeInForce string
+type CandleInterval string
+type CandleType string
+type CurrencyStatus string
+type AddressStatus string
+type DepositStatus string
+type WithdrawalStatus string
+type OrderCancelType string
+type ConditionalOperand string
+type ConditionalOrderStatus string
 
 const (
 	OrderSideBuy  OrderSide = "BUY"
@@ -31,15 +45,53 @@ const (
 	TimeInForcePOGTC       TimeInForce = "POST_ONLY_GOOD_TIL_CANCELLED"
 	TimeInForceBN          TimeInForce = "BUY_NOW"
 	TimeInForceINST        TimeInForce = "INSTANT"
+
+	CandleIntervalMinute1 CandleInterval = "MINUTE_1"
+	CandleIntervalMinute5 CandleInterval = "MINUTE_5"
+	CandleIntervalHour1   CandleInterval = "HOUR_1"
+	CandleIntervalDay1    CandleInterval = "DAY_1"
+	CandleTypeTrade       CandleType     = "TRADE"
+	CandleTypeMidpoint    CandleType     = "MIDPOINT"
+
+	CurrencyStatusOnline  CurrencyStatus = "ONLINE"
+	CurrencyStatusOffline CurrencyStatus = "OFFLINE"
+
+	AddressStatusRequested   AddressStatus = "REQUESTED"
+	AddressStatusProvisioned AddressStatus = "PROVISIONED"
+
+	DepositStatusPending     DepositStatus = "PENDING"
+	DepositStatusCompleted   DepositStatus = "COMPLETED"
+	DepositStatusOrphaned    DepositStatus = "ORPHANED"
+	DepositStatusInvalidated DepositStatus = "INVALIDATED"
+
+	WithdrawalStatusRequested           WithdrawalStatus = "REQUESTED"
+	WithdrawalStatusAuthorized          WithdrawalStatus = "AUTHORIZED"
+	WithdrawalStatusPending             WithdrawalStatus = "PENDING"
+	WithdrawalStatusCompleted           WithdrawalStatus = "COMPLETED"
+	WithdrawalStatusErrorInvalidAddress WithdrawalStatus = "ERROR_INVALID_ADDRESS"
+	WithdrawalStatusCancelled           WithdrawalStatus = "CANCELLED"
+
+	OrderCancelTypeOrder            OrderCancelType        = "ORDER"
+	OrderCancelTypeConditionalOrder OrderCancelType        = "CONDITIONAL_ORDER"
+	ConditionalOperandLTE           ConditionalOperand     = "LTE"
+	ConditionalOperandGTE           ConditionalOperand     = "GTE"
+	ConditionalOrderStatusOpen      ConditionalOrderStatus = "OPEN"
+	ConditionalOrderStatusCompleted ConditionalOrderStatus = "COMPLETED"
+	ConditionalOrderStatusCancelled ConditionalOrderStatus = "CANCELLED"
+	ConditionalOrderStatusFailed    ConditionalOrderStatus = "FAILED"
 )
 
 func NewBittrexAPI(client Client, uri string) *BittrexAPI {
-	return &BittrexAPI{client: client, uri: uri}
+	return &BittrexAPI{client: client, uri: uri, markets: &marketRules{}, now: time.Now}
 }
 
 func (this *BittrexAPI) GetMarket(symbol string) (Market, error) {
+	return this.GetMarketCtx(context.Background(), symbol)
+}
+
+func (this *BittrexAPI) GetMarketCtx(ctx context.Context, symbol string) (Market, error) {
 	uri := this.uri + "/markets/" + symbol
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return Market{}, err
 	}
@@ -53,8 +105,12 @@ func (this *BittrexAPI) GetMarket(symbol string) (Market, error) {
 }
 
 func (this *BittrexAPI) GetMarkets() ([]Market, error) {
+	return this.GetMarketsCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetMarketsCtx(ctx context.Context) ([]Market, error) {
 	uri := this.uri + "/markets"
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +124,12 @@ func (this *BittrexAPI) GetMarkets() ([]Market, error) {
 }
 
 func (this *BittrexAPI) GetMarketSummary(symbol string) (MarketSummary, error) {
+	return this.GetMarketSummaryCtx(context.Background(), symbol)
+}
+
+func (this *BittrexAPI) GetMarketSummaryCtx(ctx context.Context, symbol string) (MarketSummary, error) {
 	uri := this.uri + "/markets/" + symbol + "/summary"
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return MarketSummary{}, err
 	}
@@ -83,8 +143,12 @@ func (this *BittrexAPI) GetMarketSummary(symbol string) (MarketSummary, error) {
 }
 
 func (this *BittrexAPI) GetMarketSummaries() ([]MarketSummary, error) {
+	return this.GetMarketSummariesCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetMarketSummariesCtx(ctx context.Context) ([]MarketSummary, error) {
 	uri := this.uri + "/markets/summaries"
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -97,9 +161,80 @@ func (this *BittrexAPI) GetMarketSummaries() ([]MarketSummary, error) {
 	return marketSummaries, nil
 }
 
+//candleType is optional and defaults to TRADE
+func (this *BittrexAPI) GetRecentCandles(symbol string, interval CandleInterval, candleType CandleType) ([]Candle, error) {
+	return this.GetRecentCandlesCtx(context.Background(), symbol, interval, candleType)
+}
+
+func (this *BittrexAPI) GetRecentCandlesCtx(ctx context.Context, symbol string, interval CandleInterval, candleType CandleType) ([]Candle, error) {
+	uri := this.uri + candlesPath(symbol, interval, candleType) + "/recent"
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var candles []Candle
+	if err := json.Unmarshal(body, &candles); err != nil {
+		return nil, err
+	}
+
+	return candles, nil
+}
+
+//MINUTE_1 and MINUTE_5 return a day, HOUR_1 a month (day is ignored) and DAY_1 a year (month and day are ignored).
+//candleType is optional, TRADE by default.
+func (this *BittrexAPI) GetHistoricalCandles(symbol string, interval CandleInterval, candleType CandleType, year int, month int, day int) ([]Candle, error) {
+	return this.GetHistoricalCandlesCtx(context.Background(), symbol, interval, candleType, year, month, day)
+}
+
+func (this *BittrexAPI) GetHistoricalCandlesCtx(ctx context.Context, symbol string, interval CandleInterval, candleType CandleType, year int, month int, day int) ([]Candle, error) {
+	bucket, err := historicalCandlesBucket(interval, year, month, day)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := this.uri + candlesPath(symbol, interval, candleType) + "/historical/" + bucket
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var candles []Candle
+	if err := json.Unmarshal(body, &candles); err != nil {
+		return nil, err
+	}
+
+	return candles, nil
+}
+
+//candleType is left out of the path when empty
+func candlesPath(symbol string, interval CandleInterval, candleType CandleType) string {
+	path := "/markets/" + symbol + "/candles/"
+	if candleType != "" {
+		path += string(candleType) + "/"
+	}
+	return path + string(interval)
+}
+
+func historicalCandlesBucket(interval CandleInterval, year int, month int, day int) (string, error) {
+	switch interval {
+	case CandleIntervalMinute1, CandleIntervalMinute5:
+		return strconv.Itoa(year) + "/" + strconv.Itoa(month) + "/" + strconv.Itoa(day), nil
+	case CandleIntervalHour1:
+		return strconv.Itoa(year) + "/" + strconv.Itoa(month), nil
+	case CandleIntervalDay1:
+		return strconv.Itoa(year), nil
+	}
+	return "", errors.New("unsupported candle interval " + string(interval))
+}
+
 func (this *BittrexAPI) GetMarketTicker(symbol string) (MarketTicker, error) {
+	return this.GetMarketTickerCtx(context.Background(), symbol)
+}
+
+func (this *BittrexAPI) GetMarketTickerCtx(ctx context.Context, symbol string) (MarketTicker, error) {
 	uri := this.uri + "/markets/" + symbol + "/ticker"
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return MarketTicker{}, err
 	}
@@ -113,8 +248,12 @@ func (this *BittrexAPI) GetMarketTicker(symbol string) (MarketTicker, error) {
 }
 
 func (this *BittrexAPI) GetMarketTickers() ([]MarketTicker, error) {
+	return this.GetMarketTickersCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetMarketTickersCtx(ctx context.Context) ([]MarketTicker, error) {
 	uri := this.uri + "/markets/tickers"
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -127,9 +266,71 @@ func (this *BittrexAPI) GetMarketTickers() ([]MarketTicker, error) {
 	return marketTickers, nil
 }
 
+//Depth must be one of 1, 25 or 500
+func (this *BittrexAPI) GetOrderBook(symbol string, depth int) (OrderBook, error) {
+	return this.GetOrderBookCtx(context.Background(), symbol, depth)
+}
+
+func (this *BittrexAPI) GetOrderBookCtx(ctx context.Context, symbol string, depth int) (OrderBook, error) {
+	if !validOrderBookDepth(depth) {
+		return OrderBook{}, errors.New("invalid order book depth " + strconv.Itoa(depth) + ", must be one of 1, 25 or 500")
+	}
+
+	uri := this.uri + "/markets/" + symbol + "/orderbook?depth=" + strconv.Itoa(depth)
+	body, header, err := this.client.DoWithHeader(ctx, "GET", uri, "", false)
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	orderBook := OrderBook{}
+	if err := json.Unmarshal(body, &orderBook); err != nil {
+		return OrderBook{}, err
+	}
+
+	if sequence := header.Get("Sequence"); sequence != "" {
+		orderBook.Sequence, err = strconv.ParseInt(sequence, 10, 64)
+		if err != nil {
+			return OrderBook{}, err
+		}
+	}
+
+	return orderBook, nil
+}
+
+func validOrderBookDepth(depth int) bool {
+	switch depth {
+	case 1, 25, 500:
+		return true
+	}
+	return false
+}
+
+func (this *BittrexAPI) GetMarketTrades(symbol string) ([]Trade, error) {
+	return this.GetMarketTradesCtx(context.Background(), symbol)
+}
+
+func (this *BittrexAPI) GetMarketTradesCtx(ctx context.Context, symbol string) ([]Trade, error) {
+	uri := this.uri + "/markets/" + symbol + "/trades"
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []Trade
+	if err := json.Unmarshal(body, &trades); err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
+
 func (this *BittrexAPI) GetCurrency(symbol string) (Currency, error) {
+	return this.GetCurrencyCtx(context.Background(), symbol)
+}
+
+func (this *BittrexAPI) GetCurrencyCtx(ctx context.Context, symbol string) (Currency, error) {
 	uri := this.uri + "/currencies/" + symbol
-	body, err := this.client.Do("GET", uri, "", false)
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
 	if err != nil {
 		return Currency{}, err
 	}
@@ -142,9 +343,137 @@ func (this *BittrexAPI) GetCurrency(symbol string) (Currency, error) {
 	return currency, nil
 }
 
+func (this *BittrexAPI) GetCurrencies() ([]Currency, error) {
+	return this.GetCurrenciesCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetCurrenciesCtx(ctx context.Context) ([]Currency, error) {
+	uri := this.uri + "/currencies"
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var currencies []Currency
+	if err := json.Unmarshal(body, &currencies); err != nil {
+		return nil, err
+	}
+
+	return currencies, nil
+}
+
+func (this *BittrexAPI) GetAccount() (Account, error) {
+	return this.GetAccountCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetAccountCtx(ctx context.Context) (Account, error) {
+	uri := this.uri + "/account"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return Account{}, err
+	}
+
+	account := Account{}
+	if err := json.Unmarshal(body, &account); err != nil {
+		return Account{}, err
+	}
+
+	return account, nil
+}
+
+func (this *BittrexAPI) GetAccountFees() ([]TradingFee, error) {
+	return this.GetAccountFeesCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetAccountFeesCtx(ctx context.Context) ([]TradingFee, error) {
+	uri := this.uri + "/account/fees/trading"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var fees []TradingFee
+	if err := json.Unmarshal(body, &fees); err != nil {
+		return nil, err
+	}
+
+	return fees, nil
+}
+
+func (this *BittrexAPI) GetAccountFee(marketSymbol string) (TradingFee, error) {
+	return this.GetAccountFeeCtx(context.Background(), marketSymbol)
+}
+
+func (this *BittrexAPI) GetAccountFeeCtx(ctx context.Context, marketSymbol string) (TradingFee, error) {
+	uri := this.uri + "/account/fees/trading/" + marketSymbol
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return TradingFee{}, err
+	}
+
+	fee := TradingFee{}
+	if err := json.Unmarshal(body, &fee); err != nil {
+		return TradingFee{}, err
+	}
+
+	return fee, nil
+}
+
+func (this *BittrexAPI) GetAccountVolume() (AccountVolume, error) {
+	return this.GetAccountVolumeCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetAccountVolumeCtx(ctx context.Context) (AccountVolume, error) {
+	uri := this.uri + "/account/volume"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return AccountVolume{}, err
+	}
+
+	volume := AccountVolume{}
+	if err := json.Unmarshal(body, &volume); err != nil {
+		return AccountVolume{}, err
+	}
+
+	return volume, nil
+}
+
+func (this *BittrexAPI) GetAccountPermissions() (AccountPermissions, error) {
+	return this.GetAccountPermissionsCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetAccountPermissionsCtx(ctx context.Context) (AccountPermissions, error) {
+	uri := this.uri + "/account/permissions/markets"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return AccountPermissions{}, err
+	}
+
+	permissions := AccountPermissions{}
+	if err := json.Unmarshal(body, &permissions.Markets); err != nil {
+		return AccountPermissions{}, err
+	}
+
+	uri = this.uri + "/account/permissions/currencies"
+	body, err = this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return AccountPermissions{}, err
+	}
+
+	if err := json.Unmarshal(body, &permissions.Currencies); err != nil {
+		return AccountPermissions{}, err
+	}
+
+	return permissions, nil
+}
+
 func (this *BittrexAPI) GetBalances() ([]Balance, error) {
+	return this.GetBalancesCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetBalancesCtx(ctx context.Context) ([]Balance, error) {
 	uri := this.uri + "/balances"
-	body, err := this.client.Do("GET", uri, "", true)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
 		return nil, err
 	}
@@ -157,142 +486,904 @@ func (this *BittrexAPI) GetBalances() ([]Balance, error) {
 	return balances, nil
 }
 
-func (this *BittrexAPI) GetOrder(orderID string) (Order, error) {
-	uri := this.uri + "/orders/" + orderID
-	body, err := this.client.Do("GET", uri, "", true)
+func (this *BittrexAPI) GetBalance(currencySymbol string) (Balance, error) {
+	return this.GetBalanceCtx(context.Background(), currencySymbol)
+}
+
+func (this *BittrexAPI) GetBalanceCtx(ctx context.Context, currencySymbol string) (Balance, error) {
+	uri := this.uri + "/balances/" + currencySymbol
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
-		return Order{}, err
+		return Balance{}, err
 	}
 
-	order := Order{}
-	if err := json.Unmarshal(body, &order); err != nil {
-		return Order{}, err
+	balance := Balance{}
+	if err := json.Unmarshal(body, &balance); err != nil {
+		return Balance{}, err
 	}
 
-	return order, nil
+	return balance, nil
 }
 
-func (this *BittrexAPI) GetOrderExecutions(orderID string) ([]*Execution, error) {
-	uri := this.uri + "/orders/" + orderID + "/executions"
-	body, err := this.client.Do("GET", uri, "", true)
+func (this *BittrexAPI) GetAddresses() ([]Address, error) {
+	return this.GetAddressesCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetAddressesCtx(ctx context.Context) ([]Address, error) {
+	uri := this.uri + "/addresses"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
 		return nil, err
 	}
 
-	var executions []*Execution
-	if err := json.Unmarshal(body, &executions); err != nil {
+	var addresses []Address
+	if err := json.Unmarshal(body, &addresses); err != nil {
 		return nil, err
 	}
 
-	return executions, nil
+	return addresses, nil
 }
 
-func (this *BittrexAPI) GetOrders(openOrClosed string) ([]Order, error) {
-	uri := this.uri + "/orders/" + openOrClosed
-	body, err := this.client.Do("GET", uri, "", true)
+func (this *BittrexAPI) GetAddress(currencySymbol string) (Address, error) {
+	return this.GetAddressCtx(context.Background(), currencySymbol)
+}
+
+func (this *BittrexAPI) GetAddressCtx(ctx context.Context, currencySymbol string) (Address, error) {
+	uri := this.uri + "/addresses/" + currencySymbol
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
-		return nil, err
+		return Address{}, err
 	}
 
-	var orders []Order
-	if err := json.Unmarshal(body, &orders); err != nil {
-		return nil, err
+	address := Address{}
+	if err := json.Unmarshal(body, &address); err != nil {
+		return Address{}, err
 	}
 
-	return orders, nil
+	return address, nil
 }
 
-//Required marketSymbol, direction, type, timeInForce
-func (this *BittrexAPI) CreateOrder(order Order) (*Order, error) {
-	payload, err := json.Marshal(order)
+//Requests a new deposit address, its status stays REQUESTED until Bittrex provisions it
+func (this *BittrexAPI) ProvisionAddress(currencySymbol string) (Address, error) {
+	return this.ProvisionAddressCtx(context.Background(), currencySymbol)
+}
+
+func (this *BittrexAPI) ProvisionAddressCtx(ctx context.Context, currencySymbol string) (Address, error) {
+	payload, err := json.Marshal(Address{CurrencySymbol: currencySymbol})
+	if err != nil {
+		return Address{}, err
+	}
+
+	uri := this.uri + "/addresses"
+	body, err := this.client.Do(ctx, "POST", uri, string(payload), true)
+	if err != nil {
+		return Address{}, err
+	}
+
+	address := Address{}
+	if err := json.Unmarshal(body, &address); err != nil {
+		return Address{}, err
+	}
+
+	return address, nil
+}
+
+//currencySymbol is optional
+func (this *BittrexAPI) GetOpenDeposits(currencySymbol string) ([]Deposit, error) {
+	return this.GetOpenDepositsCtx(context.Background(), currencySymbol)
+}
+
+func (this *BittrexAPI) GetOpenDepositsCtx(ctx context.Context, currencySymbol string) ([]Deposit, error) {
+	query := url.Values{}
+	if currencySymbol != "" {
+		query.Set("currencySymbol", currencySymbol)
+	}
+
+	uri := this.uri + "/deposits/open" + encodeQuery(query)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
 		return nil, err
 	}
 
-	uri := this.uri + "/orders"
-	body, err := this.client.Do("POST", uri, string(payload), true)
+	var deposits []Deposit
+	if err := json.Unmarshal(body, &deposits); err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+func (this *BittrexAPI) GetClosedDeposits(filter DepositFilter) ([]Deposit, error) {
+	return this.GetClosedDepositsCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetClosedDepositsCtx(ctx context.Context, filter DepositFilter) ([]Deposit, error) {
+	uri := this.uri + "/deposits/closed" + encodeQuery(filter.query())
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
 		return nil, err
 	}
 
-	var returnOrder *Order
-	if err := json.Unmarshal(body, &returnOrder); err != nil {
-		return nil, errors.New(err.Error() + string(body))
+	var deposits []Deposit
+	if err := json.Unmarshal(body, &deposits); err != nil {
+		return nil, err
 	}
 
-	return returnOrder, nil
+	return deposits, nil
 }
 
-func (this *BittrexAPI) CancelOrder(orderId string) (*Order, error) {
-	uri := this.uri + "/orders/" + orderId
-	body, err := this.client.Do("DELETE", uri, "", true)
+func (this *BittrexAPI) GetDepositsByTxID(txID string) ([]Deposit, error) {
+	return this.GetDepositsByTxIDCtx(context.Background(), txID)
+}
+
+func (this *BittrexAPI) GetDepositsByTxIDCtx(ctx context.Context, txID string) ([]Deposit, error) {
+	uri := this.uri + "/deposits/ByTxId/" + url.PathEscape(txID)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
 	if err != nil {
 		return nil, err
 	}
-	returnOrder := new(Order)
-	if err := json.Unmarshal(body, &returnOrder); err != nil {
-		return nil, errors.New(err.Error() + string(body))
+
+	var deposits []Deposit
+	if err := json.Unmarshal(body, &deposits); err != nil {
+		return nil, err
 	}
 
-	return returnOrder, nil
+	return deposits, nil
 }
 
-//////////////////////////////////////////
-type Currency struct {
-	Symbol           string `json:"symbol"`
-	Name             string `json:"name"`
-	CoinType         string `json:"coinType"`
-	Status           string `json:"status"`
-	MinConfirmations string `json:"minConfirmations"`
-	Notice           string `json:"notice"`
-	TxFee            string `json:"txFee"`
-	LogoUrl          string `json:"logoUrl"`
-	ProhibitedIn     string `json:"prohibitedIn"`
-	BaseAddress      string `json:"baseAddress"`
+//Required currencySymbol, quantity, cryptoAddress
+func (this *BittrexAPI) CreateWithdrawal(withdrawal NewWithdrawal) (Withdrawal, error) {
+	return this.CreateWithdrawalCtx(context.Background(), withdrawal)
 }
 
-type Balance struct {
-	CurrencySymbol string `json:"currencySymbol"`
-	Total          string `json:"total"`
-	Available      string `json:"available"`
-	UpdatedAt      string `json:"updatedAt"`
+func (this *BittrexAPI) CreateWithdrawalCtx(ctx context.Context, withdrawal NewWithdrawal) (Withdrawal, error) {
+	payload, err := json.Marshal(withdrawal)
+	if err != nil {
+		return Withdrawal{}, err
+	}
+
+	uri := this.uri + "/withdrawals"
+	body, err := this.client.Do(ctx, "POST", uri, string(payload), true)
+	if err != nil {
+		return Withdrawal{}, err
+	}
+
+	returnWithdrawal := Withdrawal{}
+	if err := json.Unmarshal(body, &returnWithdrawal); err != nil {
+		return Withdrawal{}, err
+	}
+
+	return returnWithdrawal, nil
 }
 
-type Market struct {
-	Symbol              string   `json:"symbol"`
-	BaseCurrencySymbol  string   `json:"baseCurrencySymbol"`
-	QuoteCurrencySymbol string   `json:"quoteCurrencySymbol"`
-	MinTradeSize        string   `json:"minTradeSize"`
-	Precision           int32    `json:"precision"`
-	Status              string   `json:"status"`
-	CreatedAt           string   `json:"createdAt"`
-	Notice              string   `json:"notice"`
-	ProhibitedIn        []string `json:"prohibitedIn"`
+func (this *BittrexAPI) CancelWithdrawal(withdrawalID string) (Withdrawal, error) {
+	return this.CancelWithdrawalCtx(context.Background(), withdrawalID)
 }
 
-type MarketSummary struct {
-	Symbol        string           `json:"symbol"`
-	High          *decimal.Decimal `json:"high,string"`
-	Low           *decimal.Decimal `json:"low,string"`
-	Volume        *decimal.Decimal `json:"volume,string"`
-	QuoteVolume   *decimal.Decimal `json:"quoteVolume,string"`
-	PercentChange *decimal.Decimal `json:"percentChange,string"`
-	UpdatedAt     string           `json:"updatedAt"`
+func (this *BittrexAPI) CancelWithdrawalCtx(ctx context.Context, withdrawalID string) (Withdrawal, error) {
+	uri := this.uri + "/withdrawals/" + withdrawalID
+	body, err := this.client.Do(ctx, "DELETE", uri, "", true)
+	if err != nil {
+		return Withdrawal{}, err
+	}
+
+	withdrawal := Withdrawal{}
+	if err := json.Unmarshal(body, &withdrawal); err != nil {
+		return Withdrawal{}, err
+	}
+
+	return withdrawal, nil
 }
 
-type MarketTicker struct {
-	Symbol        string           `json:"symbol"`
-	LastTradeRate *decimal.Decimal `json:"lastTradeRate,string"`
-	BidRate       *decimal.Decimal `json:"bidRate,string"`
-	AskRate       *decimal.Decimal `json:"askRate,string"`
+func (this *BittrexAPI) GetWithdrawal(withdrawalID string) (Withdrawal, error) {
+	return this.GetWithdrawalCtx(context.Background(), withdrawalID)
 }
 
-type Order struct {
-	OrderID       string           `json:"id,omitempty"`
-	MarketSymbol  string           `json:"marketSymbol"` //Required
-	Direction     OrderSide        `json:"direction"`    //Required - Buy, Sell
-	OrderType     OrderType        `json:"type"`         //Required - LIMIT, MARKET, CEILING_LIMIT, CEILING_MARKET
-	Quantity      *decimal.Decimal `json:"quantity,string,omitempty"`
+func (this *BittrexAPI) GetWithdrawalCtx(ctx context.Context, withdrawalID string) (Withdrawal, error) {
+	uri := this.uri + "/withdrawals/" + withdrawalID
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return Withdrawal{}, err
+	}
+
+	withdrawal := Withdrawal{}
+	if err := json.Unmarshal(body, &withdrawal); err != nil {
+		return Withdrawal{}, err
+	}
+
+	return withdrawal, nil
+}
+
+//status and currencySymbol are optional
+func (this *BittrexAPI) GetOpenWithdrawals(status WithdrawalStatus, currencySymbol string) ([]Withdrawal, error) {
+	return this.GetOpenWithdrawalsCtx(context.Background(), status, currencySymbol)
+}
+
+func (this *BittrexAPI) GetOpenWithdrawalsCtx(ctx context.Context, status WithdrawalStatus, currencySymbol string) ([]Withdrawal, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+	if currencySymbol != "" {
+		query.Set("currencySymbol", currencySymbol)
+	}
+
+	uri := this.uri + "/withdrawals/open" + encodeQuery(query)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var withdrawals []Withdrawal
+	if err := json.Unmarshal(body, &withdrawals); err != nil {
+		return nil, err
+	}
+
+	return withdrawals, nil
+}
+
+func (this *BittrexAPI) GetClosedWithdrawals(filter WithdrawalFilter) ([]Withdrawal, error) {
+	return this.GetClosedWithdrawalsCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetClosedWithdrawalsCtx(ctx context.Context, filter WithdrawalFilter) ([]Withdrawal, error) {
+	uri := this.uri + "/withdrawals/closed" + encodeQuery(filter.query())
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var withdrawals []Withdrawal
+	if err := json.Unmarshal(body, &withdrawals); err != nil {
+		return nil, err
+	}
+
+	return withdrawals, nil
+}
+
+func (this *BittrexAPI) GetWithdrawalsByTxID(txID string) ([]Withdrawal, error) {
+	return this.GetWithdrawalsByTxIDCtx(context.Background(), txID)
+}
+
+func (this *BittrexAPI) GetWithdrawalsByTxIDCtx(ctx context.Context, txID string) ([]Withdrawal, error) {
+	uri := this.uri + "/withdrawals/ByTxId/" + url.PathEscape(txID)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var withdrawals []Withdrawal
+	if err := json.Unmarshal(body, &withdrawals); err != nil {
+		return nil, err
+	}
+
+	return withdrawals, nil
+}
+
+func (this *BittrexAPI) GetAllowedWithdrawalAddresses() ([]AllowedAddress, error) {
+	return this.GetAllowedWithdrawalAddressesCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetAllowedWithdrawalAddressesCtx(ctx context.Context) ([]AllowedAddress, error) {
+	uri := this.uri + "/withdrawals/allowed-addresses"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []AllowedAddress
+	if err := json.Unmarshal(body, &addresses); err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+func (this *BittrexAPI) GetOrder(orderID string) (Order, error) {
+	return this.GetOrderCtx(context.Background(), orderID)
+}
+
+func (this *BittrexAPI) GetOrderCtx(ctx context.Context, orderID string) (Order, error) {
+	uri := this.uri + "/orders/" + orderID
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return Order{}, err
+	}
+
+	order := Order{}
+	if err := json.Unmarshal(body, &order); err != nil {
+		return Order{}, err
+	}
+
+	return order, nil
+}
+
+func (this *BittrexAPI) GetOrderExecutions(orderID string) ([]*Execution, error) {
+	return this.GetOrderExecutionsCtx(context.Background(), orderID)
+}
+
+func (this *BittrexAPI) GetOrderExecutionsCtx(ctx context.Context, orderID string) ([]*Execution, error) {
+	uri := this.uri + "/orders/" + orderID + "/executions"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []*Execution
+	if err := json.Unmarshal(body, &executions); err != nil {
+		return nil, err
+	}
+
+	return executions, nil
+}
+
+func (this *BittrexAPI) GetExecutions(filter ExecutionFilter) ([]*Execution, error) {
+	return this.GetExecutionsCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetExecutionsCtx(ctx context.Context, filter ExecutionFilter) ([]*Execution, error) {
+	uri := this.uri + "/executions" + encodeQuery(filter.query())
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []*Execution
+	if err := json.Unmarshal(body, &executions); err != nil {
+		return nil, err
+	}
+
+	return executions, nil
+}
+
+func (this *BittrexAPI) GetExecution(executionID string) (Execution, error) {
+	return this.GetExecutionCtx(context.Background(), executionID)
+}
+
+func (this *BittrexAPI) GetExecutionCtx(ctx context.Context, executionID string) (Execution, error) {
+	uri := this.uri + "/executions/" + executionID
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return Execution{}, err
+	}
+
+	execution := Execution{}
+	if err := json.Unmarshal(body, &execution); err != nil {
+		return Execution{}, err
+	}
+
+	return execution, nil
+}
+
+//ID of the most recent execution on the account, to sweep new executions from
+func (this *BittrexAPI) GetLastExecutionID() (string, error) {
+	return this.GetLastExecutionIDCtx(context.Background())
+}
+
+func (this *BittrexAPI) GetLastExecutionIDCtx(ctx context.Context) (string, error) {
+	uri := this.uri + "/executions/last-id"
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return "", err
+	}
+
+	var lastID struct {
+		LastID string `json:"lastId"`
+	}
+	if err := json.Unmarshal(body, &lastID); err != nil {
+		return "", err
+	}
+
+	return lastID.LastID, nil
+}
+
+func (this *BittrexAPI) GetOrders(openOrClosed string) ([]Order, error) {
+	return this.GetOrdersCtx(context.Background(), openOrClosed)
+}
+
+func (this *BittrexAPI) GetOrdersCtx(ctx context.Context, openOrClosed string) ([]Order, error) {
+	uri := this.uri + "/orders/" + openOrClosed
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	if err := json.Unmarshal(body, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+//One page of closed orders, unlike GetOrders("closed") the filter selects the market, the page and the date window
+func (this *BittrexAPI) GetClosedOrders(filter OrderFilter) ([]Order, error) {
+	return this.GetClosedOrdersCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetClosedOrdersCtx(ctx context.Context, filter OrderFilter) ([]Order, error) {
+	uri := this.uri + "/orders/closed" + encodeQuery(filter.query())
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	if err := json.Unmarshal(body, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+//Required marketSymbol, direction, type, timeInForce
+func (this *BittrexAPI) CreateOrder(order Order) (*Order, error) {
+	return this.CreateOrderCtx(context.Background(), order)
+}
+
+func (this *BittrexAPI) CreateOrderCtx(ctx context.Context, order Order) (*Order, error) {
+	if markets := this.markets.load(); markets != nil {
+		market, err := markets.get(ctx, order.MarketSymbol)
+		if err != nil {
+			return nil, err
+		}
+		if order, err = market.NormalizeOrder(order); err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := this.uri + "/orders"
+	body, err := this.client.Do(ctx, "POST", uri, string(payload), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var returnOrder *Order
+	if err := json.Unmarshal(body, &returnOrder); err != nil {
+		return nil, errors.New(err.Error() + string(body))
+	}
+
+	return returnOrder, nil
+}
+
+func (this *BittrexAPI) CancelOrder(orderId string) (*Order, error) {
+	return this.CancelOrderCtx(context.Background(), orderId)
+}
+
+func (this *BittrexAPI) CancelOrderCtx(ctx context.Context, orderId string) (*Order, error) {
+	uri := this.uri + "/orders/" + orderId
+	body, err := this.client.Do(ctx, "DELETE", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+	returnOrder := new(Order)
+	if err := json.Unmarshal(body, &returnOrder); err != nil {
+		return nil, errors.New(err.Error() + string(body))
+	}
+
+	return returnOrder, nil
+}
+
+//marketSymbol is optional, all open orders are cancelled when empty
+func (this *BittrexAPI) CancelAllOpenOrders(marketSymbol string) ([]CancelResult, error) {
+	return this.CancelAllOpenOrdersCtx(context.Background(), marketSymbol)
+}
+
+func (this *BittrexAPI) CancelAllOpenOrdersCtx(ctx context.Context, marketSymbol string) ([]CancelResult, error) {
+	query := url.Values{}
+	if marketSymbol != "" {
+		query.Set("marketSymbol", marketSymbol)
+	}
+
+	uri := this.uri + "/orders/open" + encodeQuery(query)
+	body, err := this.client.Do(ctx, "DELETE", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []CancelResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, errors.New(err.Error() + string(body))
+	}
+
+	return results, nil
+}
+
+//Required marketSymbol, operand and one of triggerPrice or trailingStopPercent
+func (this *BittrexAPI) CreateConditionalOrder(order NewConditionalOrder) (ConditionalOrder, error) {
+	return this.CreateConditionalOrderCtx(context.Background(), order)
+}
+
+func (this *BittrexAPI) CreateConditionalOrderCtx(ctx context.Context, order NewConditionalOrder) (ConditionalOrder, error) {
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return ConditionalOrder{}, err
+	}
+
+	uri := this.uri + "/conditional-orders"
+	body, err := this.client.Do(ctx, "POST", uri, string(payload), true)
+	if err != nil {
+		return ConditionalOrder{}, err
+	}
+
+	returnOrder := ConditionalOrder{}
+	if err := json.Unmarshal(body, &returnOrder); err != nil {
+		return ConditionalOrder{}, errors.New(err.Error() + string(body))
+	}
+
+	return returnOrder, nil
+}
+
+func (this *BittrexAPI) CancelConditionalOrder(conditionalOrderID string) (ConditionalOrder, error) {
+	return this.CancelConditionalOrderCtx(context.Background(), conditionalOrderID)
+}
+
+func (this *BittrexAPI) CancelConditionalOrderCtx(ctx context.Context, conditionalOrderID string) (ConditionalOrder, error) {
+	uri := this.uri + "/conditional-orders/" + conditionalOrderID
+	body, err := this.client.Do(ctx, "DELETE", uri, "", true)
+	if err != nil {
+		return ConditionalOrder{}, err
+	}
+
+	returnOrder := ConditionalOrder{}
+	if err := json.Unmarshal(body, &returnOrder); err != nil {
+		return ConditionalOrder{}, errors.New(err.Error() + string(body))
+	}
+
+	return returnOrder, nil
+}
+
+func (this *BittrexAPI) GetConditionalOrder(conditionalOrderID string) (ConditionalOrder, error) {
+	return this.GetConditionalOrderCtx(context.Background(), conditionalOrderID)
+}
+
+func (this *BittrexAPI) GetConditionalOrderCtx(ctx context.Context, conditionalOrderID string) (ConditionalOrder, error) {
+	uri := this.uri + "/conditional-orders/" + conditionalOrderID
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return ConditionalOrder{}, err
+	}
+
+	order := ConditionalOrder{}
+	if err := json.Unmarshal(body, &order); err != nil {
+		return ConditionalOrder{}, err
+	}
+
+	return order, nil
+}
+
+//marketSymbol is optional
+func (this *BittrexAPI) GetOpenConditionalOrders(marketSymbol string) ([]ConditionalOrder, error) {
+	return this.GetOpenConditionalOrdersCtx(context.Background(), marketSymbol)
+}
+
+func (this *BittrexAPI) GetOpenConditionalOrdersCtx(ctx context.Context, marketSymbol string) ([]ConditionalOrder, error) {
+	query := url.Values{}
+	if marketSymbol != "" {
+		query.Set("marketSymbol", marketSymbol)
+	}
+
+	uri := this.uri + "/conditional-orders/open" + encodeQuery(query)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []ConditionalOrder
+	if err := json.Unmarshal(body, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (this *BittrexAPI) GetClosedConditionalOrders(filter ConditionalOrderFilter) ([]ConditionalOrder, error) {
+	return this.GetClosedConditionalOrdersCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetClosedConditionalOrdersCtx(ctx context.Context, filter ConditionalOrderFilter) ([]ConditionalOrder, error) {
+	uri := this.uri + "/conditional-orders/closed" + encodeQuery(filter.query())
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []ConditionalOrder
+	if err := json.Unmarshal(body, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+//////////////////////////////////////////
+type Currency struct {
+	Symbol           string          `json:"symbol"`
+	Name             string          `json:"name"`
+	CoinType         string          `json:"coinType"`
+	Status           CurrencyStatus  `json:"status"` //ONLINE, OFFLINE
+	MinConfirmations int             `json:"minConfirmations"`
+	Notice           string          `json:"notice"`
+	TxFee            decimal.Decimal `json:"txFee"`
+	LogoUrl          string          `json:"logoUrl"`
+	ProhibitedIn     []string        `json:"prohibitedIn"`
+	BaseAddress      string          `json:"baseAddress"`
+}
+
+type Account struct {
+	SubaccountID  string   `json:"subaccountId"`
+	AccountID     string   `json:"accountId"`
+	ActionsNeeded []string `json:"actionsNeeded"`
+}
+
+type TradingFee struct {
+	MarketSymbol string          `json:"marketSymbol"`
+	MakerRate    decimal.Decimal `json:"makerRate"`
+	TakerRate    decimal.Decimal `json:"takerRate"`
+}
+
+//Commission charged on proceeds when filled as maker or taker
+func (this TradingFee) Commission(proceeds decimal.Decimal, isTaker bool) decimal.Decimal {
+	if isTaker {
+		return proceeds.Mul(this.TakerRate)
+	}
+	return proceeds.Mul(this.MakerRate)
+}
+
+//Reports whether the order commission lies between that of an all maker and an all taker fill of its proceeds,
+//allowing for the exchange rounding to 8 decimals
+func (this TradingFee) Reconciles(order Order) bool {
+	if order.Commission == nil || order.Proceeds == nil {
+		return false
+	}
+
+	lower := this.Commission(*order.Proceeds, false)
+	upper := this.Commission(*order.Proceeds, true)
+	if lower.GreaterThan(upper) {
+		lower, upper = upper, lower
+	}
+
+	tolerance := decimal.New(1, -8)
+	return order.Commission.GreaterThanOrEqual(lower.Sub(tolerance)) && order.Commission.LessThanOrEqual(upper.Add(tolerance))
+}
+
+type AccountVolume struct {
+	Updated      time.Time       `json:"updated"`
+	Volume30days decimal.Decimal `json:"volume30days"`
+}
+
+type AccountPermissions struct {
+	Markets    []MarketPermission
+	Currencies []CurrencyPermission
+}
+
+type MarketPermission struct {
+	Symbol string `json:"symbol"`
+	View   bool   `json:"view"`
+	Buy    bool   `json:"buy"`
+	Sell   bool   `json:"sell"`
+}
+
+type CurrencyPermission struct {
+	Symbol   string                  `json:"symbol"`
+	View     bool                    `json:"view"`
+	Deposit  FundsTransferPermission `json:"deposit"`
+	Withdraw FundsTransferPermission `json:"withdraw"`
+}
+
+type FundsTransferPermission struct {
+	Blockchain   bool `json:"blockchain"`
+	CreditCard   bool `json:"creditCard"`
+	WireTransfer bool `json:"wireTransfer"`
+	ACH          bool `json:"ach"`
+}
+
+type Balance struct {
+	CurrencySymbol string          `json:"currencySymbol"`
+	Total          decimal.Decimal `json:"total"`
+	Available      decimal.Decimal `json:"available"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+//Keys balances by their currency symbol
+func BalancesBySymbol(balances []Balance) map[string]Balance {
+	bySymbol := make(map[string]Balance, len(balances))
+	for _, balance := range balances {
+		bySymbol[balance.CurrencySymbol] = balance
+	}
+	return bySymbol
+}
+
+type Address struct {
+	Status           AddressStatus `json:"status,omitempty"` //REQUESTED, PROVISIONED
+	CurrencySymbol   string        `json:"currencySymbol"`
+	CryptoAddress    string        `json:"cryptoAddress,omitempty"`
+	CryptoAddressTag string        `json:"cryptoAddressTag,omitempty"`
+}
+
+//Pagination and date window accepted by the closed history endpoints, zero values are left out of the request
+type PageFilter struct {
+	NextPageToken     string
+	PreviousPageToken string
+	PageSize          int
+	StartDate         time.Time
+	EndDate           time.Time
+}
+
+func (this PageFilter) encode(query url.Values) {
+	if this.NextPageToken != "" {
+		query.Set("nextPageToken", this.NextPageToken)
+	}
+	if this.PreviousPageToken != "" {
+		query.Set("previousPageToken", this.PreviousPageToken)
+	}
+	if this.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(this.PageSize))
+	}
+	if !this.StartDate.IsZero() {
+		query.Set("startDate", this.StartDate.UTC().Format(time.RFC3339))
+	}
+	if !this.EndDate.IsZero() {
+		query.Set("endDate", this.EndDate.UTC().Format(time.RFC3339))
+	}
+}
+
+func encodeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+type DepositFilter struct {
+	Status         DepositStatus //COMPLETED, ORPHANED, INVALIDATED
+	CurrencySymbol string
+	PageFilter
+}
+
+func (this DepositFilter) query() url.Values {
+	query := url.Values{}
+	if this.Status != "" {
+		query.Set("status", string(this.Status))
+	}
+	if this.CurrencySymbol != "" {
+		query.Set("currencySymbol", this.CurrencySymbol)
+	}
+	this.PageFilter.encode(query)
+	return query
+}
+
+type Deposit struct {
+	ID               string          `json:"id"`
+	CurrencySymbol   string          `json:"currencySymbol"`
+	Quantity         decimal.Decimal `json:"quantity"`
+	CryptoAddress    string          `json:"cryptoAddress"`
+	CryptoAddressTag string          `json:"cryptoAddressTag"`
+	TxID             string          `json:"txId"`
+	Confirmations    int             `json:"confirmations"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+	CompletedAt      time.Time       `json:"completedAt"`
+	Status           DepositStatus   `json:"status"` //PENDING, COMPLETED, ORPHANED, INVALIDATED
+	Source           string          `json:"source"` //BLOCKCHAIN, WIRE_TRANSFER, CREDIT_CARD, ACH, AIRDROP
+}
+
+type NewWithdrawal struct {
+	CurrencySymbol     string          `json:"currencySymbol"` //Required
+	Quantity           decimal.Decimal `json:"quantity"`       //Required
+	CryptoAddress      string          `json:"cryptoAddress"`  //Required
+	CryptoAddressTag   string          `json:"cryptoAddressTag,omitempty"`
+	ClientWithdrawalID string          `json:"clientWithdrawalId,omitempty"`
+}
+
+type WithdrawalFilter struct {
+	Status         WithdrawalStatus //COMPLETED, CANCELLED
+	CurrencySymbol string
+	PageFilter
+}
+
+func (this WithdrawalFilter) query() url.Values {
+	query := url.Values{}
+	if this.Status != "" {
+		query.Set("status", string(this.Status))
+	}
+	if this.CurrencySymbol != "" {
+		query.Set("currencySymbol", this.CurrencySymbol)
+	}
+	this.PageFilter.encode(query)
+	return query
+}
+
+type Withdrawal struct {
+	ID                 string           `json:"id"`
+	CurrencySymbol     string           `json:"currencySymbol"`
+	Quantity           decimal.Decimal  `json:"quantity"`
+	CryptoAddress      string           `json:"cryptoAddress"`
+	CryptoAddressTag   string           `json:"cryptoAddressTag"`
+	TxCost             decimal.Decimal  `json:"txCost"`
+	TxID               string           `json:"txId"`
+	Status             WithdrawalStatus `json:"status"` //REQUESTED, AUTHORIZED, PENDING, COMPLETED, ERROR_INVALID_ADDRESS, CANCELLED
+	CreatedAt          time.Time        `json:"createdAt"`
+	CompletedAt        time.Time        `json:"completedAt"`
+	ClientWithdrawalID string           `json:"clientWithdrawalId"`
+}
+
+type AllowedAddress struct {
+	CurrencySymbol   string    `json:"currencySymbol"`
+	CryptoAddress    string    `json:"cryptoAddress"`
+	CryptoAddressTag string    `json:"cryptoAddressTag"`
+	ActiveAt         time.Time `json:"activeAt"`
+	Status           string    `json:"status"` //ACTIVE, PENDING
+}
+
+type Market struct {
+	Symbol              string   `json:"symbol"`
+	BaseCurrencySymbol  string   `json:"baseCurrencySymbol"`
+	QuoteCurrencySymbol string   `json:"quoteCurrencySymbol"`
+	MinTradeSize        string   `json:"minTradeSize"`
+	Precision           int32    `json:"precision"`
+	Status              string   `json:"status"`
+	CreatedAt           string   `json:"createdAt"`
+	Notice              string   `json:"notice"`
+	ProhibitedIn        []string `json:"prohibitedIn"`
+}
+
+type MarketSummary struct {
+	Symbol        string           `json:"symbol"`
+	High          *decimal.Decimal `json:"high,string"`
+	Low           *decimal.Decimal `json:"low,string"`
+	Volume        *decimal.Decimal `json:"volume,string"`
+	QuoteVolume   *decimal.Decimal `json:"quoteVolume,string"`
+	PercentChange *decimal.Decimal `json:"percentChange,string"`
+	UpdatedAt     string           `json:"updatedAt"`
+}
+
+type Candle struct {
+	StartsAt    time.Time       `json:"startsAt"`
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Close       decimal.Decimal `json:"close"`
+	Volume      decimal.Decimal `json:"volume"`
+	QuoteVolume decimal.Decimal `json:"quoteVolume"`
+}
+
+type MarketTicker struct {
+	Symbol        string           `json:"symbol"`
+	LastTradeRate *decimal.Decimal `json:"lastTradeRate,string"`
+	BidRate       *decimal.Decimal `json:"bidRate,string"`
+	AskRate       *decimal.Decimal `json:"askRate,string"`
+}
+
+type OrderBook struct {
+	Bid      []OrderBookEntry `json:"bid"`
+	Ask      []OrderBookEntry `json:"ask"`
+	Sequence int64            `json:"-"` // Sequence response header
+}
+
+type OrderBookEntry struct {
+	Quantity decimal.Decimal `json:"quantity"`
+	Rate     decimal.Decimal `json:"rate"`
+}
+
+type Trade struct {
+	ID         string          `json:"id"`
+	ExecutedAt time.Time       `json:"executedAt"`
+	Quantity   decimal.Decimal `json:"quantity"`
+	Rate       decimal.Decimal `json:"rate"`
+	TakerSide  OrderSide       `json:"takerSide"`
+}
+
+type Order struct {
+	OrderID       string           `json:"id,omitempty"`
+	MarketSymbol  string           `json:"marketSymbol"` //Required
+	Direction     OrderSide        `json:"direction"`    //Required - Buy, Sell
+	OrderType     OrderType        `json:"type"`         //Required - LIMIT, MARKET, CEILING_LIMIT, CEILING_MARKET
+	Quantity      *decimal.Decimal `json:"quantity,string,omitempty"`
 	Limit         *decimal.Decimal `json:"limit,string,omitempty"`
 	Ceiling       *decimal.Decimal `json:"ceiling,string,omitempty"`
 	TimeInForce   TimeInForce      `json:"timeInForce,omitempty"` //GOOD_TIL_CANCELLED, IMMEDIATE_OR_CANCEL, FILL_OR_KILL, POST_ONLY_GOOD_TIL_CANCELLED, BUY_NOW
@@ -310,8 +1401,96 @@ type Order struct {
 }
 
 type OrderCancel struct {
-	OrderType OrderType `json:"type,omitempty"`
-	ID        string    `json:"id,omitempty"`
+	OrderType OrderCancelType `json:"type,omitempty"` //ORDER, CONDITIONAL_ORDER
+	ID        string          `json:"id,omitempty"`
+}
+
+type CancelResult struct {
+	ID         string `json:"id"`
+	StatusCode string `json:"statusCode"`
+	Result     *Order `json:"result"` //Result.Code is set when the order could not be cancelled
+}
+
+//*APIError when the order could not be cancelled, nil otherwise. A statusCode that is not a number, e.g. "OK",
+//says nothing about the outcome, so only Result.Code is considered then.
+func (this CancelResult) Err() error {
+	statusCode, err := strconv.Atoi(this.StatusCode)
+	if err != nil {
+		if this.Result != nil && this.Result.Code != nil {
+			return &APIError{Code: *this.Result.Code}
+		}
+		return nil
+	}
+	return bulkResultError(statusCode, this.Result)
+}
+
+type NewConditionalOrder struct {
+	MarketSymbol             string             `json:"marketSymbol"` //Required
+	Operand                  ConditionalOperand `json:"operand"`      //Required - LTE, GTE
+	TriggerPrice             *decimal.Decimal   `json:"triggerPrice,omitempty"`
+	TrailingStopPercent      *decimal.Decimal   `json:"trailingStopPercent,omitempty"`
+	OrderToCreate            *Order             `json:"orderToCreate,omitempty"`
+	OrderToCancel            *OrderCancel       `json:"orderToCancel,omitempty"`
+	ClientConditionalOrderID string             `json:"clientConditionalOrderId,omitempty"`
+}
+
+type ConditionalOrderFilter struct {
+	MarketSymbol string
+	PageFilter
+}
+
+func (this ConditionalOrderFilter) query() url.Values {
+	query := url.Values{}
+	if this.MarketSymbol != "" {
+		query.Set("marketSymbol", this.MarketSymbol)
+	}
+	this.PageFilter.encode(query)
+	return query
+}
+
+type ConditionalOrder struct {
+	ID                       string                 `json:"id"`
+	MarketSymbol             string                 `json:"marketSymbol"`
+	Operand                  ConditionalOperand     `json:"operand"`
+	TriggerPrice             *decimal.Decimal       `json:"triggerPrice"`
+	TrailingStopPercent      *decimal.Decimal       `json:"trailingStopPercent"`
+	CreatedOrderID           string                 `json:"createdOrderId"`
+	OrderToCreate            *Order                 `json:"orderToCreate"`
+	OrderToCancel            *OrderCancel           `json:"orderToCancel"`
+	ClientConditionalOrderID string                 `json:"clientConditionalOrderId"`
+	Status                   ConditionalOrderStatus `json:"status"` //OPEN, COMPLETED, CANCELLED, FAILED
+	OrderCreationErrorCode   string                 `json:"orderCreationErrorCode"`
+	CreatedAt                time.Time              `json:"createdAt"`
+	UpdatedAt                time.Time              `json:"updatedAt"`
+	ClosedAt                 time.Time              `json:"closedAt"`
+}
+
+type OrderFilter struct {
+	MarketSymbol string
+	PageFilter
+}
+
+func (this OrderFilter) query() url.Values {
+	query := url.Values{}
+	if this.MarketSymbol != "" {
+		query.Set("marketSymbol", this.MarketSymbol)
+	}
+	this.PageFilter.encode(query)
+	return query
+}
+
+type ExecutionFilter struct {
+	MarketSymbol string
+	PageFilter
+}
+
+func (this ExecutionFilter) query() url.Values {
+	query := url.Values{}
+	if this.MarketSymbol != "" {
+		query.Set("marketSymbol", this.MarketSymbol)
+	}
+	this.PageFilter.encode(query)
+	return query
 }
 
 type Execution struct {
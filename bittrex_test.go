@@ -1,6 +1,7 @@
 package bittrex
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
@@ -30,25 +31,353 @@ func (this *BittrexAPIFixture) TestGetCurrency() {
 	this.So(result, should.Resemble, Currency{})
 }
 
+func (this *BittrexAPIFixture) TestGetCurrencies() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetCurrencies()
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []Currency{
+		{
+			Symbol:           "BTC",
+			Name:             "Bitcoin",
+			CoinType:         "BITCOIN",
+			Status:           CurrencyStatusOnline,
+			MinConfirmations: 2,
+			Notice:           "",
+			TxFee:            decimal.NewFromFloatWithExponent(0.0005, -8),
+			LogoUrl:          "https://bittrexblobstorage.blob.core.windows.net/public/btc.png",
+			ProhibitedIn:     []string{},
+			BaseAddress:      "",
+		}, {
+			Symbol:           "4ART",
+			Name:             "4ART Coin",
+			CoinType:         "ETH_CONTRACT",
+			Status:           CurrencyStatusOffline,
+			MinConfirmations: 36,
+			Notice:           "Wallet maintenance",
+			TxFee:            decimal.RequireFromString("84.00000000"),
+			LogoUrl:          "",
+			ProhibitedIn:     []string{"US"},
+			BaseAddress:      "0xfbb1b73c4f0bda4f67dca266ce6ef42f520fbb98",
+		},
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetAccount() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAccount()
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, Account{
+		SubaccountID:  "",
+		AccountID:     "5b5bb3c1-ec12-4ee1-8f1b-7a74e6a8e4a2",
+		ActionsNeeded: []string{},
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetAccountFees() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAccountFees()
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []TradingFee{
+		{
+			MarketSymbol: "ETH-BTC",
+			MakerRate:    decimal.NewFromFloat(0.0035),
+			TakerRate:    decimal.NewFromFloat(0.0035),
+		}, {
+			MarketSymbol: "XRP-BTC",
+			MakerRate:    decimal.NewFromFloat(0.0012),
+			TakerRate:    decimal.NewFromFloat(0.0025),
+		},
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetAccountFee() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAccountFee("XRP-BTC")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, TradingFee{
+		MarketSymbol: "XRP-BTC",
+		MakerRate:    decimal.NewFromFloat(0.0012),
+		TakerRate:    decimal.NewFromFloat(0.0025),
+	})
+}
+
+func (this *BittrexAPIFixture) TestTradingFeeReconcilesOrderCommission() {
+	fee := TradingFee{
+		MarketSymbol: "XRP-BTC",
+		MakerRate:    decimal.NewFromFloat(0.0012),
+		TakerRate:    decimal.NewFromFloat(0.0025),
+	}
+	proceeds := decimal.NewFromFloat(0.00272829)
+	makerCommission := decimal.NewFromFloat(0.00000327)
+	takerCommission := decimal.NewFromFloat(0.00000682)
+	overcharged := decimal.NewFromFloat(0.00000954)
+	this.So(fee.Commission(proceeds, true).Round(8), should.Resemble, takerCommission)
+	this.So(fee.Reconciles(Order{Proceeds: &proceeds, Commission: &makerCommission}), should.BeTrue)
+	this.So(fee.Reconciles(Order{Proceeds: &proceeds, Commission: &takerCommission}), should.BeTrue)
+	this.So(fee.Reconciles(Order{Proceeds: &proceeds, Commission: &overcharged}), should.BeFalse)
+	this.So(fee.Reconciles(Order{}), should.BeFalse)
+}
+
+func (this *BittrexAPIFixture) TestGetAccountVolume() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAccountVolume()
+	updated, _ := time.Parse(time.RFC3339, "2020-09-08T00:00:00Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, AccountVolume{
+		Updated:      updated,
+		Volume30days: decimal.NewFromFloat(1.25432),
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetAccountPermissions() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAccountPermissions()
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, AccountPermissions{
+		Markets: []MarketPermission{{Symbol: "ETH-BTC", View: true, Buy: true, Sell: false}},
+		Currencies: []CurrencyPermission{{
+			Symbol:   "BTC",
+			View:     true,
+			Deposit:  FundsTransferPermission{Blockchain: true},
+			Withdraw: FundsTransferPermission{Blockchain: true, WireTransfer: true},
+		}},
+	})
+}
+
 func (this *BittrexAPIFixture) TestGetBalances() {
 	client := &fakeBittrexClient{}
 	bittrex := NewBittrexAPI(client, "")
 	result, err := bittrex.GetBalances()
+	firstUpdatedAt, _ := time.Parse(time.RFC3339, "2019-10-29T20:25:10.16Z")
+	secondUpdatedAt, _ := time.Parse(time.RFC3339, "2020-09-03T21:27:53.8210894Z")
 	this.So(err, should.BeNil)
 	this.So(result, should.Resemble, []Balance{
 		{
 			CurrencySymbol: "BTC",
-			Total:          "0.00000000",
-			Available:      "0.00000000",
-			UpdatedAt:      "2019-10-29T20:25:10.16Z",
+			Total:          decimal.RequireFromString("0.00000000"),
+			Available:      decimal.RequireFromString("0.00000000"),
+			UpdatedAt:      firstUpdatedAt,
 		}, {
 			CurrencySymbol: "LTC",
-			Total:          "0",
-			Available:      "0",
-			UpdatedAt:      "2020-09-03T21:27:53.8210894Z",
+			Total:          decimal.RequireFromString("0"),
+			Available:      decimal.RequireFromString("0"),
+			UpdatedAt:      secondUpdatedAt,
 		},
 	})
 }
+
+func (this *BittrexAPIFixture) TestGetBalance() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetBalance("BTC")
+	updatedAt, _ := time.Parse(time.RFC3339, "2019-10-29T20:25:10.16Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, Balance{
+		CurrencySymbol: "BTC",
+		Total:          decimal.NewFromFloat(1.5),
+		Available:      decimal.NewFromFloat(0.25),
+		UpdatedAt:      updatedAt,
+	})
+}
+
+func (this *BittrexAPIFixture) TestBalancesBySymbol() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	balances, _ := bittrex.GetBalances()
+	result := BalancesBySymbol(balances)
+	this.So(len(result), should.Equal, 2)
+	this.So(result["BTC"], should.Resemble, balances[0])
+	this.So(result["LTC"], should.Resemble, balances[1])
+}
+
+func (this *BittrexAPIFixture) TestGetAddresses() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAddresses()
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []Address{
+		{
+			Status:         AddressStatusProvisioned,
+			CurrencySymbol: "BTC",
+			CryptoAddress:  "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		}, {
+			Status:           AddressStatusProvisioned,
+			CurrencySymbol:   "XRP",
+			CryptoAddress:    "rPVMhWBsfF9iMXYj3aAzJVkPDTFNSyWdKy",
+			CryptoAddressTag: "380279815",
+		},
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetAddress() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAddress("BTC")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, Address{
+		Status:         AddressStatusProvisioned,
+		CurrencySymbol: "BTC",
+		CryptoAddress:  "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+	})
+}
+
+func (this *BittrexAPIFixture) TestProvisionAddress() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.ProvisionAddress("4ART")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, Address{
+		Status:         AddressStatusRequested,
+		CurrencySymbol: "4ART",
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetOpenDeposits() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetOpenDeposits("BTC")
+	updatedAt, _ := time.Parse(time.RFC3339, "2020-09-08T05:08:40.84Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []Deposit{{
+		ID:             "d3a8e5f2-4c1b-4a8e-9c8a-1f2d3c4b5a69",
+		CurrencySymbol: "BTC",
+		Quantity:       decimal.NewFromFloat(0.5),
+		CryptoAddress:  "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		TxID:           "8e4f6b1c2d3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c",
+		Confirmations:  1,
+		UpdatedAt:      updatedAt,
+		Status:         DepositStatusPending,
+		Source:         "BLOCKCHAIN",
+	}})
+}
+
+func (this *BittrexAPIFixture) TestGetClosedDeposits() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	startDate, _ := time.Parse(time.RFC3339, "2020-09-01T00:00:00Z")
+	result, err := bittrex.GetClosedDeposits(DepositFilter{
+		Status:         DepositStatusCompleted,
+		CurrencySymbol: "BTC",
+		PageFilter: PageFilter{
+			NextPageToken: "d3a8e5f2-4c1b-4a8e-9c8a-1f2d3c4b5a69",
+			PageSize:      100,
+			StartDate:     startDate,
+		},
+	})
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].Status, should.Equal, DepositStatusCompleted)
+	this.So(result[0].Confirmations, should.Equal, 2)
+
+	result, err = bittrex.GetClosedDeposits(DepositFilter{})
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+}
+
+func (this *BittrexAPIFixture) TestGetDepositsByTxID() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetDepositsByTxID("8e4f6b1c2d3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c")
+	completedAt, _ := time.Parse(time.RFC3339, "2020-09-08T05:18:40.84Z")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].TxID, should.Equal, "8e4f6b1c2d3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c")
+	this.So(result[0].CompletedAt, should.Resemble, completedAt)
+}
+
+func (this *BittrexAPIFixture) TestCreateWithdrawal() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.CreateWithdrawal(NewWithdrawal{
+		CurrencySymbol:     "BTC",
+		Quantity:           decimal.NewFromFloat(0.5),
+		CryptoAddress:      "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		ClientWithdrawalID: "0b8b9f6c-8d4b-4a1f-9d7a-2e5c3b1a0f9e",
+	})
+	createdAt, _ := time.Parse(time.RFC3339, "2020-09-08T05:08:40.84Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, Withdrawal{
+		ID:                 "c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d",
+		CurrencySymbol:     "BTC",
+		Quantity:           decimal.NewFromFloat(0.5),
+		CryptoAddress:      "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		TxCost:             decimal.NewFromFloat(0.0005),
+		Status:             WithdrawalStatusRequested,
+		CreatedAt:          createdAt,
+		ClientWithdrawalID: "0b8b9f6c-8d4b-4a1f-9d7a-2e5c3b1a0f9e",
+	})
+}
+
+func (this *BittrexAPIFixture) TestCancelWithdrawal() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.CancelWithdrawal("c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d")
+	this.So(err, should.BeNil)
+	this.So(result.Status, should.Equal, WithdrawalStatusCancelled)
+}
+
+func (this *BittrexAPIFixture) TestGetWithdrawal() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetWithdrawal("c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d")
+	this.So(err, should.BeNil)
+	this.So(result.ID, should.Equal, "c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d")
+	this.So(result.Status, should.Equal, WithdrawalStatusRequested)
+}
+
+func (this *BittrexAPIFixture) TestGetOpenWithdrawals() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetOpenWithdrawals(WithdrawalStatusRequested, "BTC")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].Status, should.Equal, WithdrawalStatusRequested)
+}
+
+func (this *BittrexAPIFixture) TestGetClosedWithdrawals() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	endDate, _ := time.Parse(time.RFC3339, "2020-09-30T00:00:00Z")
+	result, err := bittrex.GetClosedWithdrawals(WithdrawalFilter{
+		Status:     WithdrawalStatusCompleted,
+		PageFilter: PageFilter{PreviousPageToken: "c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d", EndDate: endDate},
+	})
+	completedAt, _ := time.Parse(time.RFC3339, "2020-09-08T06:08:40.84Z")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].Status, should.Equal, WithdrawalStatusCompleted)
+	this.So(result[0].CompletedAt, should.Resemble, completedAt)
+}
+
+func (this *BittrexAPIFixture) TestGetWithdrawalsByTxID() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetWithdrawalsByTxID("5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].TxID, should.Equal, "5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e")
+}
+
+func (this *BittrexAPIFixture) TestGetAllowedWithdrawalAddresses() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetAllowedWithdrawalAddresses()
+	activeAt, _ := time.Parse(time.RFC3339, "2020-09-01T00:00:00Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []AllowedAddress{{
+		CurrencySymbol: "BTC",
+		CryptoAddress:  "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		ActiveAt:       activeAt,
+		Status:         "ACTIVE",
+	}})
+}
+
 func (this *BittrexAPIFixture) TestGetMarket() {
 	client := &fakeBittrexClient{}
 	bittrex := NewBittrexAPI(client, "")
@@ -67,6 +396,20 @@ func (this *BittrexAPIFixture) TestGetMarket() {
 	})
 }
 
+func (this *BittrexAPIFixture) TestGetMarketCtx() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetMarketCtx(context.Background(), "fakesymbol")
+	this.So(err, should.BeNil)
+	this.So(result.Symbol, should.Equal, "ETH-BTC")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = bittrex.GetMarketCtx(ctx, "fakesymbol")
+	this.So(errors.Is(err, context.Canceled), should.BeTrue)
+	this.So(result, should.Resemble, Market{})
+}
+
 func (this *BittrexAPIFixture) TestGetMarkets() {
 	client := &fakeBittrexClient{}
 	bittrex := NewBittrexAPI(client, "")
@@ -155,6 +498,51 @@ func (this *BittrexAPIFixture) TestGetMarketSummaries() {
 	})
 }
 
+func (this *BittrexAPIFixture) TestGetRecentCandles() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetRecentCandles("fakesymbol", CandleIntervalHour1, CandleTypeMidpoint)
+	startsAt, _ := time.Parse(time.RFC3339, "2020-09-04T04:00:00Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []Candle{{
+		StartsAt:    startsAt,
+		Open:        decimal.NewFromFloat(0.0376),
+		High:        decimal.NewFromFloat(0.03894964),
+		Low:         decimal.NewFromFloat(0.0365),
+		Close:       decimal.NewFromFloat(0.03760069),
+		Volume:      decimal.NewFromFloat(18494.04035144),
+		QuoteVolume: decimal.NewFromFloat(696.42899671),
+	}})
+
+	result, err = bittrex.GetRecentCandles("fakesymbol", CandleIntervalHour1, "")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+}
+
+func (this *BittrexAPIFixture) TestGetHistoricalCandles() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetHistoricalCandles("fakesymbol", CandleIntervalMinute5, "", 2020, 9, 4)
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+
+	result, err = bittrex.GetHistoricalCandles("fakesymbol", CandleIntervalHour1, "", 2020, 9, 4)
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+
+	result, err = bittrex.GetHistoricalCandles("fakesymbol", CandleIntervalDay1, "", 2020, 9, 4)
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+
+	result, err = bittrex.GetHistoricalCandles("fakesymbol", CandleIntervalHour1, CandleTypeMidpoint, 2020, 9, 4)
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+
+	result, err = bittrex.GetHistoricalCandles("fakesymbol", "MINUTE_3", "", 2020, 9, 4)
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+}
+
 func (this *BittrexAPIFixture) TestGetMarketTicker() {
 	client := &fakeBittrexClient{}
 	bittrex := NewBittrexAPI(client, "")
@@ -198,6 +586,57 @@ func (this *BittrexAPIFixture) TestGetMarketTickers() {
 	})
 }
 
+func (this *BittrexAPIFixture) TestGetOrderBook() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetOrderBook("fakesymbol", 1)
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, OrderBook{
+		Bid: []OrderBookEntry{{
+			Quantity: decimal.NewFromFloat(1.5),
+			Rate:     decimal.NewFromFloat(0.03760103),
+		}},
+		Ask: []OrderBookEntry{{
+			Quantity: decimal.NewFromFloat(0.25),
+			Rate:     decimal.NewFromFloat(0.03762798),
+		}},
+		Sequence: 31337,
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetOrderBookInvalidDepth() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetOrderBook("fakesymbol", 10)
+	this.So(err, should.NotBeNil)
+	this.So(result, should.Resemble, OrderBook{})
+}
+
+func (this *BittrexAPIFixture) TestGetMarketTrades() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetMarketTrades("fakesymbol")
+	firstTradeTime, _ := time.Parse(time.RFC3339, "2020-09-04T04:37:45.107Z")
+	secondTradeTime, _ := time.Parse(time.RFC3339, "2020-09-04T04:37:46.2Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []Trade{
+		{
+			ID:         "b0dd5d5e-2b53-4bd1-8a53-0dd8cc1fcb94",
+			ExecutedAt: firstTradeTime,
+			Quantity:   decimal.NewFromFloat(0.41),
+			Rate:       decimal.NewFromFloat(0.03760069),
+			TakerSide:  OrderSideBuy,
+		},
+		{
+			ID:         "5f5e1c09-0a7e-4d58-8cb6-c4b4a9ef1d23",
+			ExecutedAt: secondTradeTime,
+			Quantity:   decimal.NewFromFloat(2.03),
+			Rate:       decimal.NewFromFloat(0.0376),
+			TakerSide:  OrderSideSell,
+		},
+	})
+}
+
 func (this *BittrexAPIFixture) TestGetOrder() {
 	client := &fakeBittrexClient{}
 	bittrex := NewBittrexAPI(client, "")
@@ -258,6 +697,45 @@ func (this *BittrexAPIFixture) TestGetOrderExecutions() {
 	})
 }
 
+func (this *BittrexAPIFixture) TestGetExecutions() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetExecutions(ExecutionFilter{
+		MarketSymbol: "EHT-BTC",
+		PageFilter:   PageFilter{NextPageToken: "3272882f-0c1d-4f5d-9c0f-8868e1acc0af"},
+	})
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 2)
+	this.So(result[0].ID, should.Equal, "3272882f-0c1d-4f5d-9c0f-8868e1acc0af")
+	this.So(result[1].ID, should.Equal, "90a4f8bb-8fd9-4a13-983b-0e9b0b156497")
+}
+
+func (this *BittrexAPIFixture) TestGetExecution() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetExecution("3272882f-0c1d-4f5d-9c0f-8868e1acc0af")
+	executedAt, _ := time.Parse(time.RFC3339, "2017-10-20T18:27:20.763Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, Execution{
+		ID:           "3272882f-0c1d-4f5d-9c0f-8868e1acc0af",
+		MarketSymbol: "EHT-BTC",
+		ExecutedAt:   executedAt,
+		Quantity:     decimal.NewFromFloat(77.53046131),
+		Rate:         decimal.NewFromFloat(1.03760069),
+		OrderId:      "fab677a0-510e-456e-b450-8a75cea69f5d",
+		Commission:   decimal.NewFromFloat(0.00000682),
+		IsTaker:      true,
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetLastExecutionID() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetLastExecutionID()
+	this.So(err, should.BeNil)
+	this.So(result, should.Equal, "90a4f8bb-8fd9-4a13-983b-0e9b0b156497")
+}
+
 func (this *BittrexAPIFixture) TestGetOrders() {
 	client := &fakeBittrexClient{}
 	bittrex := NewBittrexAPI(client, "")
@@ -337,14 +815,13 @@ func (this *BittrexAPIFixture) TestCreateOrderError() {
 		Quantity:     &quantity,
 		Limit:        &limit,
 	}
-	minTradeError := "MIN_TRADE_REQUIREMENT_NOT_MET"
 	result, err := bittrex.CreateOrder(order)
-	this.So(err, should.BeNil)
-	this.So(result, should.Resemble, &Order{
-		Quantity: nil,
-		Limit:    nil,
-		Code:     &minTradeError,
-	})
+	this.So(errors.Is(err, ErrMinTradeRequirementNotMet), should.BeTrue)
+	this.So(result, should.BeNil)
+
+	var apiError *APIError
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(apiError.StatusCode, should.Equal, http.StatusBadRequest)
 }
 
 func (this *BittrexAPIFixture) TestCancelOrder() {
@@ -374,14 +851,128 @@ func (this *BittrexAPIFixture) TestCancelOrderError() {
 	client := &fakeBittrexClient{EnableErrors: true}
 	bittrex := NewBittrexAPI(client, "")
 	orderId := "fab677a0-510e-456e-b450-8a75cea69f5d"
-	orderNotOpen := "ORDER_NOT_OPEN"
 	result, err := bittrex.CancelOrder(orderId)
+	this.So(errors.Is(err, ErrOrderNotOpen), should.BeTrue)
+	this.So(errors.Is(err, ErrMinTradeRequirementNotMet), should.BeFalse)
+	this.So(result, should.BeNil)
+}
+
+func (this *BittrexAPIFixture) TestCancelAllOpenOrders() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.CancelAllOpenOrders("ETH-BTC")
+	orderNotOpen := "ORDER_NOT_OPEN"
 	this.So(err, should.BeNil)
-	this.So(result, should.Resemble, &Order{
-		Quantity: nil,
-		Limit:    nil,
-		Code:     &orderNotOpen,
+	this.So(len(result), should.Equal, 2)
+	this.So(result[0].ID, should.Equal, "fab677a0-510e-456e-b450-8a75cea69f5d")
+	this.So(result[0].StatusCode, should.Equal, "200")
+	this.So(result[0].Result.Status, should.Equal, "CLOSED")
+	this.So(result[0].Err(), should.BeNil)
+	this.So(errors.Is(result[1].Err(), ErrOrderNotOpen), should.BeTrue)
+	this.So(CancelResult{StatusCode: "503"}.Err(), should.Resemble, &APIError{StatusCode: 503})
+	this.So(result[1], should.Resemble, CancelResult{
+		ID:         "55eb2c82-4184-4a24-8b6e-ee154b2f7eaf",
+		StatusCode: "409",
+		Result:     &Order{Code: &orderNotOpen},
 	})
+
+	result, err = bittrex.CancelAllOpenOrders("")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 2)
+}
+
+func (this *BittrexAPIFixture) TestCancelResultNonNumericStatusCode() {
+	orderNotOpen := "ORDER_NOT_OPEN"
+	this.So(CancelResult{StatusCode: "OK", Result: &Order{Status: "CLOSED"}}.Err(), should.BeNil)
+	err := CancelResult{StatusCode: "CONFLICT", Result: &Order{Code: &orderNotOpen}}.Err()
+	this.So(err, should.Resemble, &APIError{Code: "ORDER_NOT_OPEN"})
+	this.So(errors.Is(err, ErrOrderNotOpen), should.BeTrue)
+}
+
+func (this *BittrexAPIFixture) TestCreateConditionalOrder() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	triggerPrice := decimal.NewFromFloat(0.035)
+	quantity := decimal.NewFromFloat(5)
+	result, err := bittrex.CreateConditionalOrder(NewConditionalOrder{
+		MarketSymbol: "ETH-BTC",
+		Operand:      ConditionalOperandLTE,
+		TriggerPrice: &triggerPrice,
+		OrderToCreate: &Order{
+			MarketSymbol: "ETH-BTC",
+			Direction:    OrderSideSell,
+			OrderType:    OrderTypeMarket,
+			Quantity:     &quantity,
+			TimeInForce:  TimeInForceIOC,
+		},
+		OrderToCancel: &OrderCancel{
+			OrderType: OrderCancelTypeOrder,
+			ID:        "fab677a0-510e-456e-b450-8a75cea69f5d",
+		},
+	})
+	createdAt, _ := time.Parse(time.RFC3339, "2020-09-08T05:08:40.84Z")
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, ConditionalOrder{
+		ID:           "7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d",
+		MarketSymbol: "ETH-BTC",
+		Operand:      ConditionalOperandLTE,
+		TriggerPrice: &triggerPrice,
+		OrderToCreate: &Order{
+			MarketSymbol: "ETH-BTC",
+			Direction:    OrderSideSell,
+			OrderType:    OrderTypeMarket,
+			Quantity:     &quantity,
+			TimeInForce:  TimeInForceIOC,
+		},
+		OrderToCancel: &OrderCancel{
+			OrderType: OrderCancelTypeOrder,
+			ID:        "fab677a0-510e-456e-b450-8a75cea69f5d",
+		},
+		Status:    ConditionalOrderStatusOpen,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	})
+}
+
+func (this *BittrexAPIFixture) TestCancelConditionalOrder() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.CancelConditionalOrder("7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d")
+	this.So(err, should.BeNil)
+	this.So(result.Status, should.Equal, ConditionalOrderStatusCancelled)
+}
+
+func (this *BittrexAPIFixture) TestGetConditionalOrder() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetConditionalOrder("7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d")
+	this.So(err, should.BeNil)
+	this.So(result.ID, should.Equal, "7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d")
+	this.So(result.OrderToCancel, should.Resemble, &OrderCancel{
+		OrderType: OrderCancelTypeOrder,
+		ID:        "fab677a0-510e-456e-b450-8a75cea69f5d",
+	})
+}
+
+func (this *BittrexAPIFixture) TestGetOpenConditionalOrders() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetOpenConditionalOrders("ETH-BTC")
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].Status, should.Equal, ConditionalOrderStatusOpen)
+}
+
+func (this *BittrexAPIFixture) TestGetClosedConditionalOrders() {
+	client := &fakeBittrexClient{}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetClosedConditionalOrders(ConditionalOrderFilter{MarketSymbol: "ETH-BTC", PageFilter: PageFilter{PageSize: 10}})
+	trailingStopPercent := decimal.NewFromFloat(2.5)
+	this.So(err, should.BeNil)
+	this.So(len(result), should.Equal, 1)
+	this.So(result[0].Status, should.Equal, ConditionalOrderStatusCompleted)
+	this.So(result[0].TrailingStopPercent, should.Resemble, &trailingStopPercent)
+	this.So(result[0].CreatedOrderID, should.Equal, "55eb2c82-4184-4a24-8b6e-ee154b2f7eaf")
 }
 
 ///////////////////////////////////////
@@ -390,10 +981,59 @@ type fakeBittrexClient struct {
 	EnableErrors bool
 }
 
-func (this *fakeBittrexClient) Do(method, uri, payload string, authenticate bool) ([]byte, error) {
+func (this *fakeBittrexClient) Do(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	switch uri {
 	case "/balances":
 		return []byte("[{\"currencySymbol\": \"BTC\",\"total\": \"0.00000000\",\"available\": \"0.00000000\",\"updatedAt\": \"2019-10-29T20:25:10.16Z\"},{\"currencySymbol\": \"LTC\",\"total\": \"0\",\"available\": \"0\",\"updatedAt\": \"2020-09-03T21:27:53.8210894Z\"}]"), nil
+	case "/balances/BTC":
+		return []byte("{\"currencySymbol\": \"BTC\",\"total\": \"1.5\",\"available\": \"0.25\",\"updatedAt\": \"2019-10-29T20:25:10.16Z\"}"), nil
+	case "/addresses":
+		if method == "POST" && payload == "{\"currencySymbol\":\"4ART\"}" {
+			return []byte("{\"status\": \"REQUESTED\",\"currencySymbol\": \"4ART\"}"), nil
+		}
+		return []byte("[{\"status\": \"PROVISIONED\",\"currencySymbol\": \"BTC\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\"},{\"status\": \"PROVISIONED\",\"currencySymbol\": \"XRP\",\"cryptoAddress\": \"rPVMhWBsfF9iMXYj3aAzJVkPDTFNSyWdKy\",\"cryptoAddressTag\": \"380279815\"}]"), nil
+	case "/addresses/BTC":
+		return []byte("{\"status\": \"PROVISIONED\",\"currencySymbol\": \"BTC\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\"}"), nil
+	case "/deposits/open?currencySymbol=BTC":
+		return []byte("[{\"id\": \"d3a8e5f2-4c1b-4a8e-9c8a-1f2d3c4b5a69\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"txId\": \"8e4f6b1c2d3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c\",\"confirmations\": 1,\"updatedAt\": \"2020-09-08T05:08:40.84Z\",\"status\": \"PENDING\",\"source\": \"BLOCKCHAIN\"}]"), nil
+	case "/deposits/closed?currencySymbol=BTC&nextPageToken=d3a8e5f2-4c1b-4a8e-9c8a-1f2d3c4b5a69&pageSize=100&startDate=2020-09-01T00%3A00%3A00Z&status=COMPLETED",
+		"/deposits/closed",
+		"/deposits/ByTxId/8e4f6b1c2d3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c":
+		return []byte("[{\"id\": \"d3a8e5f2-4c1b-4a8e-9c8a-1f2d3c4b5a69\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"txId\": \"8e4f6b1c2d3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c\",\"confirmations\": 2,\"updatedAt\": \"2020-09-08T05:18:40.84Z\",\"completedAt\": \"2020-09-08T05:18:40.84Z\",\"status\": \"COMPLETED\",\"source\": \"BLOCKCHAIN\"}]"), nil
+	case "/withdrawals":
+		if payload == "{\"currencySymbol\":\"BTC\",\"quantity\":\"0.5\",\"cryptoAddress\":\"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"clientWithdrawalId\":\"0b8b9f6c-8d4b-4a1f-9d7a-2e5c3b1a0f9e\"}" {
+			return []byte("{\"id\": \"c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"txCost\": \"0.0005\",\"status\": \"REQUESTED\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"clientWithdrawalId\": \"0b8b9f6c-8d4b-4a1f-9d7a-2e5c3b1a0f9e\"}"), nil
+		}
+	case "/withdrawals/c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d":
+		if method == "DELETE" {
+			return []byte("{\"id\": \"c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"status\": \"CANCELLED\"}"), nil
+		}
+		return []byte("{\"id\": \"c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"txCost\": \"0.0005\",\"status\": \"REQUESTED\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"clientWithdrawalId\": \"0b8b9f6c-8d4b-4a1f-9d7a-2e5c3b1a0f9e\"}"), nil
+	case "/withdrawals/open?currencySymbol=BTC&status=REQUESTED":
+		return []byte("[{\"id\": \"c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"txCost\": \"0.0005\",\"status\": \"REQUESTED\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"clientWithdrawalId\": \"0b8b9f6c-8d4b-4a1f-9d7a-2e5c3b1a0f9e\"}]"), nil
+	case "/withdrawals/closed?endDate=2020-09-30T00%3A00%3A00Z&previousPageToken=c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d&status=COMPLETED",
+		"/withdrawals/ByTxId/5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e":
+		return []byte("[{\"id\": \"c4a1e2b3-5d6f-4a7b-8c9d-0e1f2a3b4c5d\",\"currencySymbol\": \"BTC\",\"quantity\": \"0.5\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"txCost\": \"0.0005\",\"txId\": \"5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e\",\"status\": \"COMPLETED\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"completedAt\": \"2020-09-08T06:08:40.84Z\"}]"), nil
+	case "/withdrawals/allowed-addresses":
+		return []byte("[{\"currencySymbol\": \"BTC\",\"cryptoAddress\": \"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2\",\"activeAt\": \"2020-09-01T00:00:00Z\",\"status\": \"ACTIVE\"}]"), nil
+	case "/currencies":
+		return []byte("[{\"symbol\": \"BTC\",\"name\": \"Bitcoin\",\"coinType\": \"BITCOIN\",\"status\": \"ONLINE\",\"minConfirmations\": 2,\"notice\": \"\",\"txFee\": \"0.00050000\",\"logoUrl\": \"https://bittrexblobstorage.blob.core.windows.net/public/btc.png\",\"prohibitedIn\": [],\"baseAddress\": \"\",\"associatedTermsOfService\": []},{\"symbol\": \"4ART\",\"name\": \"4ART Coin\",\"coinType\": \"ETH_CONTRACT\",\"status\": \"OFFLINE\",\"minConfirmations\": 36,\"notice\": \"Wallet maintenance\",\"txFee\": \"84.00000000\",\"prohibitedIn\": [\"US\"],\"baseAddress\": \"0xfbb1b73c4f0bda4f67dca266ce6ef42f520fbb98\"}]"), nil
+	case "/account":
+		return []byte("{\"subaccountId\": \"\",\"accountId\": \"5b5bb3c1-ec12-4ee1-8f1b-7a74e6a8e4a2\",\"actionsNeeded\": []}"), nil
+	case "/account/fees/trading":
+		return []byte("[{\"marketSymbol\": \"ETH-BTC\",\"makerRate\": \"0.0035\",\"takerRate\": \"0.0035\"},{\"marketSymbol\": \"XRP-BTC\",\"makerRate\": \"0.0012\",\"takerRate\": \"0.0025\"}]"), nil
+	case "/account/fees/trading/XRP-BTC":
+		return []byte("{\"marketSymbol\": \"XRP-BTC\",\"makerRate\": \"0.0012\",\"takerRate\": \"0.0025\"}"), nil
+	case "/account/volume":
+		return []byte("{\"updated\": \"2020-09-08T00:00:00Z\",\"volume30days\": \"1.25432\"}"), nil
+	case "/account/permissions/markets":
+		return []byte("[{\"symbol\": \"ETH-BTC\",\"view\": true,\"buy\": true,\"sell\": false}]"), nil
+	case "/account/permissions/currencies":
+		return []byte("[{\"symbol\": \"BTC\",\"view\": true,\"deposit\": {\"blockchain\": true,\"creditCard\": false,\"wireTransfer\": false,\"ach\": false},\"withdraw\": {\"blockchain\": true,\"creditCard\": false,\"wireTransfer\": true,\"ach\": false}}]"), nil
 	case "/currencies/fakesymbol":
 		return []byte("{}"), nil
 	case "/markets/fakesymbol":
@@ -404,26 +1044,67 @@ func (this *fakeBittrexClient) Do(method, uri, payload string, authenticate bool
 		return []byte("{\"symbol\":\"ETH-BTC\",\"high\":\"0.03894964\",\"low\":\"0.03650000\",\"volume\":\"18494.04035144\",\"quoteVolume\":\"696.42899671\",\"percentChange\":\"-3.33\",\"updatedAt\":\"2020-09-04T04:37:45.107Z\"}"), nil
 	case "/markets/summaries":
 		return []byte("[{\"symbol\": \"4ART-BTC\",\"high\": \"0.00000275\",\"low\": \"0.00000249\",\"volume\": \"54499.59344453\",\"quoteVolume\": \"0.13917073\",\"percentChange\": \"10.44\",\"updatedAt\": \"2020-09-04T04:58:55.447Z\"},{\"symbol\": \"4ART-USDT\",\"high\": \"0.02880000\",\"low\": \"0.02667000\",\"volume\": \"48259.53706735\",\"quoteVolume\": \"1320.75839607\",\"percentChange\": \"-6.11\",\"updatedAt\": \"2020-09-04T04:33:20.01Z\"}]"), nil
+	case "/markets/fakesymbol/candles/MIDPOINT/HOUR_1/recent",
+		"/markets/fakesymbol/candles/HOUR_1/recent",
+		"/markets/fakesymbol/candles/MINUTE_5/historical/2020/9/4",
+		"/markets/fakesymbol/candles/HOUR_1/historical/2020/9",
+		"/markets/fakesymbol/candles/MIDPOINT/HOUR_1/historical/2020/9",
+		"/markets/fakesymbol/candles/DAY_1/historical/2020":
+		return []byte("[{\"startsAt\": \"2020-09-04T04:00:00Z\",\"open\": \"0.0376\",\"high\": \"0.03894964\",\"low\": \"0.0365\",\"close\": \"0.03760069\",\"volume\": \"18494.04035144\",\"quoteVolume\": \"696.42899671\"}]"), nil
 	case "/markets/fakesymbol/ticker":
 		return []byte("{\"symbol\":\"ETH-BTC\",\"lastTradeRate\":\"0.03760069\",\"bidRate\":\"0.03760103\",\"askRate\":\"0.03762798\"}"), nil
 	case "/markets/tickers":
 		return []byte("[{\"symbol\": \"ETH-BTC\",\"lastTradeRate\": \"0.03760069\",\"bidRate\": \"0.03760103\",\"askRate\": \"0.03762798\"},{\"symbol\": \"ETH-FAKE\",\"lastTradeRate\": \"1.03760069\",\"bidRate\": \"1.03760103\",\"askRate\": \"1.03762798\"}]"), nil
+	case "/markets/fakesymbol/orderbook?depth=1":
+		return []byte("{\"bid\": [{\"quantity\": \"1.5\",\"rate\": \"0.03760103\"}],\"ask\": [{\"quantity\": \"0.25\",\"rate\": \"0.03762798\"}]}"), nil
+	case "/markets/fakesymbol/trades":
+		return []byte("[{\"id\": \"b0dd5d5e-2b53-4bd1-8a53-0dd8cc1fcb94\",\"executedAt\": \"2020-09-04T04:37:45.107Z\",\"quantity\": \"0.41\",\"rate\": \"0.03760069\",\"takerSide\": \"BUY\"},{\"id\": \"5f5e1c09-0a7e-4d58-8cb6-c4b4a9ef1d23\",\"executedAt\": \"2020-09-04T04:37:46.2Z\",\"quantity\": \"2.03\",\"rate\": \"0.0376\",\"takerSide\": \"SELL\"}]"), nil
 	case "/orders/fakeOrder":
 		return []byte("{\"id\": \"55eb2c82-4184-4a24-8b6e-ee154b2f7eaf\",\"marketSymbol\": \"XRP-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"77.53046131\",\"limit\": \"0.00003528\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"fillQuantity\": \"77.53046131\",\"commission\": \"0.00000682\",\"proceeds\": \"0.00272829\",\"status\": \"CLOSED\",\"createdAt\": \"2017-10-20T18:27:20.747Z\",\"updatedAt\": \"2017-10-20T18:27:20.763Z\",\"closedAt\": \"2017-10-20T18:27:20.763Z\"}"), nil
 	case "/orders/open":
+		if method == "DELETE" {
+			return this.Do(ctx, method, "/orders/open?marketSymbol=ETH-BTC", payload, authenticate)
+		}
 		return []byte("[{\"id\": \"55eb2c82-4184-4a24-8b6e-ee154b2f7eaf\",\"marketSymbol\": \"XRP-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"77.53046131\",\"limit\": \"0.00003528\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"fillQuantity\": \"77.53046131\",\"commission\": \"0.00000682\",\"proceeds\": \"0.00272829\",\"status\": \"OPEN\",\"createdAt\": \"2017-10-20T18:27:20.747Z\",\"updatedAt\": \"2017-10-20T18:27:20.763Z\",\"closedAt\": \"2017-10-20T18:27:20.763Z\"}]"), nil
 	case "/orders/closed":
 		return []byte("[{\"id\": \"55eb2c82-4184-4a24-8b6e-ee154b2f7eaf\",\"marketSymbol\": \"XRP-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"77.53046131\",\"limit\": \"0.00003528\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"fillQuantity\": \"77.53046131\",\"commission\": \"0.00000682\",\"proceeds\": \"0.00272829\",\"status\": \"CLOSED\",\"createdAt\": \"2017-10-20T18:27:20.747Z\",\"updatedAt\": \"2017-10-20T18:27:20.763Z\",\"closedAt\": \"2017-10-20T18:27:20.763Z\"}]"), nil
+	case "/orders/open?marketSymbol=ETH-BTC":
+		if method == "DELETE" {
+			return []byte("[{\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\",\"statusCode\": \"200\",\"result\": {\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\",\"marketSymbol\": \"ETH-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"5\",\"limit\": \"0.00039561\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"status\": \"CLOSED\"}},{\"id\": \"55eb2c82-4184-4a24-8b6e-ee154b2f7eaf\",\"statusCode\": \"409\",\"result\": {\"code\": \"ORDER_NOT_OPEN\"}}]"), nil
+		}
 	case "/orders":
 		if this.EnableErrors {
-			return []byte("{\"code\": \"MIN_TRADE_REQUIREMENT_NOT_MET\"}"), nil
+			return nil, &APIError{StatusCode: http.StatusBadRequest, Code: "MIN_TRADE_REQUIREMENT_NOT_MET"}
 		}
 		return []byte("{\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\",\"marketSymbol\": \"ETH-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"5\",\"limit\": \"0.00039561\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"status\": \"OPEN\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"updatedAt\": \"2020-09-08T05:08:40.84Z\"}"), nil
 	case "/orders/fab677a0-510e-456e-b450-8a75cea69f5d":
 		if this.EnableErrors {
-			return []byte("{\"code\": \"ORDER_NOT_OPEN\"}"), nil
+			return nil, &APIError{StatusCode: http.StatusConflict, Code: "ORDER_NOT_OPEN"}
 		}
 		return []byte("{\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\",\"marketSymbol\": \"ETH-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"5\",\"limit\": \"0.00039561\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"status\": \"OPEN\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"updatedAt\": \"2020-09-08T05:08:40.84Z\"}"), nil
+	case "/batch":
+		if payload == "[{\"resource\":\"ORDER\",\"operation\":\"POST\",\"payload\":{\"marketSymbol\":\"ETH-BTC\",\"direction\":\"BUY\",\"type\":\"LIMIT\",\"quantity\":\"5\",\"limit\":\"0.00039561\",\"timeInForce\":\"GOOD_TIL_CANCELLED\"}},{\"resource\":\"ORDER\",\"operation\":\"DELETE\",\"payload\":{\"id\":\"55eb2c82-4184-4a24-8b6e-ee154b2f7eaf\"}}]" {
+			return []byte("[{\"status\": 201,\"payload\": {\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\",\"marketSymbol\": \"ETH-BTC\",\"direction\": \"BUY\",\"type\": \"LIMIT\",\"quantity\": \"5\",\"limit\": \"0.00039561\",\"timeInForce\": \"GOOD_TIL_CANCELLED\",\"status\": \"OPEN\"}},{\"status\": 409,\"payload\": {\"code\": \"ORDER_NOT_OPEN\"}}]"), nil
+		}
+	case "/conditional-orders":
+		if payload == "{\"marketSymbol\":\"ETH-BTC\",\"operand\":\"LTE\",\"triggerPrice\":\"0.035\",\"orderToCreate\":{\"marketSymbol\":\"ETH-BTC\",\"direction\":\"SELL\",\"type\":\"MARKET\",\"quantity\":\"5\",\"timeInForce\":\"IMMEDIATE_OR_CANCEL\"},\"orderToCancel\":{\"type\":\"ORDER\",\"id\":\"fab677a0-510e-456e-b450-8a75cea69f5d\"}}" {
+			return []byte("{\"id\": \"7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d\",\"marketSymbol\": \"ETH-BTC\",\"operand\": \"LTE\",\"triggerPrice\": \"0.035\",\"orderToCreate\": {\"marketSymbol\": \"ETH-BTC\",\"direction\": \"SELL\",\"type\": \"MARKET\",\"quantity\": \"5\",\"timeInForce\": \"IMMEDIATE_OR_CANCEL\"},\"orderToCancel\": {\"type\": \"ORDER\",\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\"},\"status\": \"OPEN\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"updatedAt\": \"2020-09-08T05:08:40.84Z\"}"), nil
+		}
+	case "/conditional-orders/7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d":
+		if method == "DELETE" {
+			return []byte("{\"id\": \"7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d\",\"marketSymbol\": \"ETH-BTC\",\"operand\": \"LTE\",\"status\": \"CANCELLED\"}"), nil
+		}
+		return []byte("{\"id\": \"7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d\",\"marketSymbol\": \"ETH-BTC\",\"operand\": \"LTE\",\"triggerPrice\": \"0.035\",\"orderToCreate\": {\"marketSymbol\": \"ETH-BTC\",\"direction\": \"SELL\",\"type\": \"MARKET\",\"quantity\": \"5\",\"timeInForce\": \"IMMEDIATE_OR_CANCEL\"},\"orderToCancel\": {\"type\": \"ORDER\",\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\"},\"status\": \"OPEN\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"updatedAt\": \"2020-09-08T05:08:40.84Z\"}"), nil
+	case "/conditional-orders/open?marketSymbol=ETH-BTC":
+		return []byte("[{\"id\": \"7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d\",\"marketSymbol\": \"ETH-BTC\",\"operand\": \"LTE\",\"triggerPrice\": \"0.035\",\"orderToCreate\": {\"marketSymbol\": \"ETH-BTC\",\"direction\": \"SELL\",\"type\": \"MARKET\",\"quantity\": \"5\",\"timeInForce\": \"IMMEDIATE_OR_CANCEL\"},\"orderToCancel\": {\"type\": \"ORDER\",\"id\": \"fab677a0-510e-456e-b450-8a75cea69f5d\"},\"status\": \"OPEN\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"updatedAt\": \"2020-09-08T05:08:40.84Z\"}]"), nil
+	case "/conditional-orders/closed?marketSymbol=ETH-BTC&pageSize=10":
+		return []byte("[{\"id\": \"7d9a6c4e-1b2f-4e3a-9c8d-5f6e7a8b9c0d\",\"marketSymbol\": \"ETH-BTC\",\"operand\": \"GTE\",\"trailingStopPercent\": \"2.5\",\"createdOrderId\": \"55eb2c82-4184-4a24-8b6e-ee154b2f7eaf\",\"status\": \"COMPLETED\",\"createdAt\": \"2020-09-08T05:08:40.84Z\",\"updatedAt\": \"2020-09-08T06:08:40.84Z\",\"closedAt\": \"2020-09-08T06:08:40.84Z\"}]"), nil
+	case "/executions?marketSymbol=EHT-BTC&nextPageToken=3272882f-0c1d-4f5d-9c0f-8868e1acc0af":
+		return this.Do(ctx, method, "/orders/fab677a0-510e-456e-b450-8a75cea69f5d/executions", payload, authenticate)
+	case "/executions/3272882f-0c1d-4f5d-9c0f-8868e1acc0af":
+		return []byte("{\"id\": \"3272882f-0c1d-4f5d-9c0f-8868e1acc0af\",\"marketSymbol\": \"EHT-BTC\",\"executedAt\": \"2017-10-20T18:27:20.763Z\",\"quantity\": \"77.53046131\", \"rate\": \"1.03760069\", \"orderId\": \"fab677a0-510e-456e-b450-8a75cea69f5d\", \"commission\": \"0.00000682\",\"isTaker\": true}"), nil
+	case "/executions/last-id":
+		return []byte("{\"lastId\": \"90a4f8bb-8fd9-4a13-983b-0e9b0b156497\"}"), nil
 	case "/orders/fab677a0-510e-456e-b450-8a75cea69f5d/executions":
 		return []byte("[{\"id\": \"3272882f-0c1d-4f5d-9c0f-8868e1acc0af\",\"marketSymbol\": \"EHT-BTC\",\"executedAt\": \"2017-10-20T18:27:20.763Z\",\"quantity\": \"77.53046131\", \"rate\": \"1.03760069\", \"orderId\": \"fab677a0-510e-456e-b450-8a75cea69f5d\", \"commission\": \"0.00000682\",\"isTaker\": true},{\"id\": \"90a4f8bb-8fd9-4a13-983b-0e9b0b156497\",\"marketSymbol\": \"EHT-BTC\",\"executedAt\": \"2017-10-20T18:27:20.793Z\",\"quantity\": \"78.53046131\", \"rate\": \"1.0376007\", \"orderId\": \"fab677a0-510e-456e-b450-8a75cea69f5d\", \"commission\": \"0.00000684\",\"isTaker\": false}]"), nil
 	}
@@ -431,6 +1112,39 @@ func (this *fakeBittrexClient) Do(method, uri, payload string, authenticate bool
 	return nil, errors.New("test resource not found")
 }
 
-func (this *fakeBittrexClient) authenticate(request *http.Request, payload string, uri string, method string) error {
-	return nil
+func (this *fakeBittrexClient) DoWithHeader(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, http.Header, error) {
+	body, err := this.Do(ctx, method, uri, payload, authenticate)
+	header := http.Header{}
+	switch uri {
+	case "/markets/fakesymbol/orderbook?depth=1":
+		header.Set("Sequence", "31337")
+	}
+	return body, header, err
+}
+
+//Serves canned responses by uri, or from fallback when set, and records every request it receives
+type recordingClient struct {
+	responses map[string]string
+	fallback  *fakeBittrexClient
+	requests  []string
+	payloads  []string
+	contexts  []context.Context
+}
+
+func (this *recordingClient) Do(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, error) {
+	this.requests = append(this.requests, uri)
+	this.payloads = append(this.payloads, payload)
+	this.contexts = append(this.contexts, ctx)
+	if response, ok := this.responses[uri]; ok {
+		return []byte(response), nil
+	}
+	if this.fallback != nil {
+		return this.fallback.Do(ctx, method, uri, payload, authenticate)
+	}
+	return nil, errors.New("test resource not found")
+}
+
+func (this *recordingClient) DoWithHeader(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, http.Header, error) {
+	body, err := this.Do(ctx, method, uri, payload, authenticate)
+	return body, http.Header{}, err
 }
@@ -0,0 +1,89 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+//Returns the candles starting in [from, to) ordered by StartsAt. Completed historical buckets (a day for MINUTE_1
+//and MINUTE_5, a month for HOUR_1 and a year for DAY_1) are requested one by one and the still open bucket is
+//filled from the recent candles. candleType is optional, TRADE by default.
+func (this *BittrexAPI) GetCandlesRange(symbol string, interval CandleInterval, candleType CandleType, from time.Time, to time.Time) ([]Candle, error) {
+	return this.GetCandlesRangeCtx(context.Background(), symbol, interval, candleType, from, to)
+}
+
+func (this *BittrexAPI) GetCandlesRangeCtx(ctx context.Context, symbol string, interval CandleInterval, candleType CandleType, from time.Time, to time.Time) ([]Candle, error) {
+	if !from.Before(to) {
+		return nil, errors.New("candle range start must be before its end")
+	}
+
+	start, err := candleBucketStart(interval, from)
+	if err != nil {
+		return nil, err
+	}
+	current, err := candleBucketStart(interval, this.now())
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make(map[int64]Candle)
+	for bucket := start; bucket.Before(to) && bucket.Before(current); bucket = nextCandleBucket(interval, bucket) {
+		historical, err := this.GetHistoricalCandlesCtx(ctx, symbol, interval, candleType, bucket.Year(), int(bucket.Month()), bucket.Day())
+		if err != nil {
+			return nil, err
+		}
+		mergeCandles(candles, historical, from, to)
+	}
+
+	if to.After(current) {
+		recent, err := this.GetRecentCandlesCtx(ctx, symbol, interval, candleType)
+		if err != nil {
+			return nil, err
+		}
+		mergeCandles(candles, recent, from, to)
+	}
+
+	result := make([]Candle, 0, len(candles))
+	for _, candle := range candles {
+		result = append(result, candle)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartsAt.Before(result[j].StartsAt)
+	})
+
+	return result, nil
+}
+
+func mergeCandles(candles map[int64]Candle, batch []Candle, from time.Time, to time.Time) {
+	for _, candle := range batch {
+		if candle.StartsAt.Before(from) || !candle.StartsAt.Before(to) {
+			continue
+		}
+		candles[candle.StartsAt.UnixNano()] = candle
+	}
+}
+
+func candleBucketStart(interval CandleInterval, t time.Time) (time.Time, error) {
+	t = t.UTC()
+	switch interval {
+	case CandleIntervalMinute1, CandleIntervalMinute5:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	case CandleIntervalHour1:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	case CandleIntervalDay1:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, errors.New("unsupported candle interval " + string(interval))
+}
+
+func nextCandleBucket(interval CandleInterval, bucket time.Time) time.Time {
+	switch interval {
+	case CandleIntervalHour1:
+		return bucket.AddDate(0, 1, 0)
+	case CandleIntervalDay1:
+		return bucket.AddDate(1, 0, 0)
+	}
+	return bucket.AddDate(0, 0, 1)
+}
@@ -0,0 +1,118 @@
+package bittrex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestCandlesRangeFixture(t *testing.T) {
+	gunit.Run(new(CandlesRangeFixture), t)
+}
+
+type CandlesRangeFixture struct {
+	*gunit.Fixture
+	now time.Time
+}
+
+func (this *CandlesRangeFixture) Setup() {
+	this.now = time.Date(2020, 10, 2, 15, 30, 0, 0, time.UTC)
+}
+
+func (this *CandlesRangeFixture) TestStitchesHistoricalBuckets() {
+	client := &recordingClient{responses: map[string]string{
+		"/markets/ETH-BTC/candles/HOUR_1/historical/2020/8":  candlesJSON("2020-08-14T23:00:00Z", "2020-08-31T23:00:00Z", "2020-08-15T00:00:00Z"),
+		"/markets/ETH-BTC/candles/HOUR_1/historical/2020/9":  candlesJSON("2020-08-31T23:00:00Z", "2020-09-01T00:00:00Z"),
+		"/markets/ETH-BTC/candles/HOUR_1/historical/2020/10": candlesJSON("2020-10-01T23:00:00Z", "2020-10-02T00:00:00Z"),
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	from, _ := time.Parse(time.RFC3339, "2020-08-15T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2020-10-02T00:00:00Z")
+	result, err := bittrex.GetCandlesRange("ETH-BTC", CandleIntervalHour1, "", from, to)
+	this.So(err, should.BeNil)
+	this.So(client.requests, should.Resemble, []string{
+		"/markets/ETH-BTC/candles/HOUR_1/historical/2020/8",
+		"/markets/ETH-BTC/candles/HOUR_1/historical/2020/9",
+		"/markets/ETH-BTC/candles/HOUR_1/historical/2020/10",
+	})
+	this.So(candleStarts(result), should.Resemble, []string{
+		"2020-08-15T00:00:00Z",
+		"2020-08-31T23:00:00Z",
+		"2020-09-01T00:00:00Z",
+		"2020-10-01T23:00:00Z",
+	})
+}
+
+func (this *CandlesRangeFixture) TestFillsCurrentBucketFromRecent() {
+	client := &recordingClient{responses: map[string]string{
+		"/markets/ETH-BTC/candles/MINUTE_5/historical/2020/10/1": candlesJSON("2020-10-01T12:00:00Z"),
+		"/markets/ETH-BTC/candles/MINUTE_5/recent":               candlesJSON("2020-10-01T12:00:00Z", "2020-10-02T00:00:00Z"),
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	bittrex.now = func() time.Time { return this.now }
+	from, _ := time.Parse(time.RFC3339, "2020-10-01T00:00:00Z")
+	result, err := bittrex.GetCandlesRange("ETH-BTC", CandleIntervalMinute5, "", from, this.now.Add(time.Hour))
+	this.So(err, should.BeNil)
+	this.So(client.requests, should.Resemble, []string{
+		"/markets/ETH-BTC/candles/MINUTE_5/historical/2020/10/1",
+		"/markets/ETH-BTC/candles/MINUTE_5/recent",
+	})
+	this.So(candleStarts(result), should.Resemble, []string{
+		"2020-10-01T12:00:00Z",
+		"2020-10-02T00:00:00Z",
+	})
+}
+
+func (this *CandlesRangeFixture) TestPassesCandleType() {
+	client := &recordingClient{responses: map[string]string{
+		"/markets/ETH-BTC/candles/MIDPOINT/MINUTE_5/historical/2020/10/1": candlesJSON("2020-10-01T12:00:00Z"),
+		"/markets/ETH-BTC/candles/MIDPOINT/MINUTE_5/recent":               candlesJSON("2020-10-02T00:00:00Z"),
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	bittrex.now = func() time.Time { return this.now }
+	from, _ := time.Parse(time.RFC3339, "2020-10-01T00:00:00Z")
+	result, err := bittrex.GetCandlesRange("ETH-BTC", CandleIntervalMinute5, CandleTypeMidpoint, from, this.now.Add(time.Hour))
+	this.So(err, should.BeNil)
+	this.So(client.requests, should.Resemble, []string{
+		"/markets/ETH-BTC/candles/MIDPOINT/MINUTE_5/historical/2020/10/1",
+		"/markets/ETH-BTC/candles/MIDPOINT/MINUTE_5/recent",
+	})
+	this.So(len(result), should.Equal, 2)
+}
+
+func (this *CandlesRangeFixture) TestInvalidRange() {
+	bittrex := NewBittrexAPI(&recordingClient{}, "")
+	now := this.now
+	result, err := bittrex.GetCandlesRange("ETH-BTC", CandleIntervalHour1, "", now, now)
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+}
+
+func (this *CandlesRangeFixture) TestUnsupportedInterval() {
+	bittrex := NewBittrexAPI(&recordingClient{}, "")
+	now := this.now
+	result, err := bittrex.GetCandlesRange("ETH-BTC", "MINUTE_3", "", now.Add(-time.Hour), now)
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+}
+
+func candlesJSON(startsAt ...string) string {
+	body := "["
+	for i, start := range startsAt {
+		if i > 0 {
+			body += ","
+		}
+		body += "{\"startsAt\": \"" + start + "\",\"open\": \"1\",\"high\": \"1\",\"low\": \"1\",\"close\": \"1\",\"volume\": \"1\",\"quoteVolume\": \"1\"}"
+	}
+	return body + "]"
+}
+
+func candleStarts(candles []Candle) []string {
+	starts := make([]string, 0, len(candles))
+	for _, candle := range candles {
+		starts = append(starts, candle.StartsAt.Format(time.RFC3339))
+	}
+	return starts
+}
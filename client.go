@@ -1,78 +1,129 @@
 package bittrex
 
 import (
-	"crypto/hmac"
-	"crypto/sha512"
-	"encoding/hex"
-	"errors"
+	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 type bittrexClient struct {
-	apiKey    string
-	secretKey string
-	client    Http
+	signer  Signer
+	client  Http
+	retry   RetryPolicy
+	limiter RateLimiter
+	sleep   func(ctx context.Context, delay time.Duration) error
+	now     func() time.Time
+	ticker  func(interval time.Duration) (<-chan time.Time, func())
+
+	clockMutex sync.RWMutex
+	clock      ClockOffset
 }
 
 func NewBittrexClient(apiKey string, secretKey string, client Http) *bittrexClient {
-	return &bittrexClient{apiKey: apiKey, secretKey: secretKey, client: client}
+	return NewBittrexClientWithSigner(NewHMACSigner(StaticCredentials{APIKey: apiKey, SecretKey: secretKey}), client)
+}
+
+//Signs requests with signer, e.g. NewHMACSigner(NewRotatingCredentials(...)) to rotate keys at runtime
+func NewBittrexClientWithSigner(signer Signer, client Http) *bittrexClient {
+	return &bittrexClient{signer: signer, client: client, sleep: sleepContext, now: time.Now, ticker: newTicker}
+}
+
+func (this *bittrexClient) Do(ctx context.Context, method string, uri string, payload string, authenticate bool) ([]byte, error) {
+	body, _, err := this.DoWithHeader(ctx, method, uri, payload, authenticate)
+	return body, err
 }
 
-func (this *bittrexClient) Do(method string, uri string, payload string, authenticate bool) ([]byte, error) {
+//Cancelling ctx or reaching its deadline aborts the request and returns ctx.Err()
+func (this *bittrexClient) DoWithHeader(ctx context.Context, method string, uri string, payload string, authenticate bool) ([]byte, http.Header, error) {
+	for attempt := 1; ; attempt++ {
+		body, header, err := this.send(ctx, method, uri, payload, authenticate)
+		if err == nil || attempt >= this.retry.MaxAttempts || !retryable(err) {
+			return body, header, err
+		}
 
-	request, err := http.NewRequest(method, uri, strings.NewReader(payload))
+		clientOrderID, idempotent := idempotentRequest(method, uri, payload)
+		if !idempotent {
+			return body, header, err
+		}
+
+		delay, ok := this.retry.delay(attempt, header)
+		if !ok {
+			return body, header, err
+		}
+		if sleepErr := this.sleep(ctx, delay); sleepErr != nil {
+			return nil, nil, sleepErr
+		}
+
+		if clientOrderID != "" {
+			existing, found, checkErr := this.findOrderByClientID(ctx, strings.TrimSuffix(uri, "/orders"), clientOrderID)
+			if checkErr != nil {
+				return nil, nil, &OrderStateUnknownError{ClientOrderID: clientOrderID, Err: err, CheckErr: checkErr}
+			}
+			if found {
+				return existing, nil, nil
+			}
+		}
+	}
+}
+
+func (this *bittrexClient) send(ctx context.Context, method string, uri string, payload string, authenticate bool) ([]byte, http.Header, error) {
+	if this.limiter != nil {
+		if err := this.limiter.Acquire(ctx, method, uri, authenticate); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, uri, strings.NewReader(payload))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if authenticate {
-		if this.authenticate(request, payload, uri, method) != nil {
-			return nil, err
+		if err := this.authenticate(ctx, request, payload); err != nil {
+			return nil, nil, err
 		}
 	}
 
 	resp, err := this.client.Do(request)
 	if err != nil {
-		return nil, err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, err
 	}
 
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		return nil, nil, err
 	}
 
-	return body, err
+	if len(body) > 0 && !json.Valid(body) {
+		return nil, resp.Header, &NonJSONResponseError{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, resp.Header, newAPIError(resp.StatusCode, resp.Header, body)
+	}
+
+	return body, resp.Header, nil
 }
 
-func (this *bittrexClient) authenticate(request *http.Request, payload string, uri string, method string) error {
-	if len(this.apiKey) == 0 || len(this.secretKey) == 0 {
-		err := errors.New("you need to set API Key and API Secret to call this method")
+func (this *bittrexClient) authenticate(ctx context.Context, request *http.Request, payload string) error {
+	if subaccountID := SubaccountID(ctx); subaccountID != "" {
+		request.Header.Set("Api-Subaccount-Id", subaccountID)
+	}
+	if err := this.signer.Sign(ctx, request, payload, this.serverNow()); err != nil {
 		return err
 	}
-	timestamp := strconv.FormatInt(time.Now().UnixNano()/1000000, 10)
-	query := request.URL.Query()
-	request.URL.RawQuery = query.Encode()
-
-	hash := sha512.New()
-	_, err := hash.Write([]byte(payload))
-	contentHash := hex.EncodeToString(hash.Sum(nil))
-
-	preSigned := strings.Join([]string{timestamp, uri, method, contentHash}, "")
-	sigHash := hmac.New(sha512.New, []byte(this.secretKey))
-	_, err = sigHash.Write([]byte(preSigned))
-	signature := hex.EncodeToString(sigHash.Sum(nil))
-
-	request.Header.Add("Api-Key", this.apiKey)
-	request.Header.Add("Api-Timestamp", timestamp)
-	request.Header.Add("Api-Content-Hash", contentHash)
-	request.Header.Add("Api-Signature", signature)
 
 	request.Header.Add("Content-Type", "application/json;charset=utf-8")
 	request.Header.Add("Accept", "application/json")
-	return err
+	return nil
 }
@@ -1,10 +1,17 @@
 package bittrex
 
 import (
+	"context"
+	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/smartystreets/assertions/should"
 	"github.com/smartystreets/gunit"
 )
 
@@ -22,7 +29,105 @@ func (this *BittrexClientFixture) Setup() {
 func (this *BittrexClientFixture) Test() {
 }
 
-type fakeHttpClient struct{}
+func (this *BittrexClientFixture) TestDoReturnsBody() {
+	httpClient := &fakeHttpClient{statusCode: http.StatusOK, body: "{\"symbol\":\"ETH-BTC\"}"}
+	client := NewBittrexClient("key", "secret", httpClient)
+	body, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets/ETH-BTC", "", false)
+	this.So(err, should.BeNil)
+	this.So(string(body), should.Equal, "{\"symbol\":\"ETH-BTC\"}")
+}
+
+func (this *BittrexClientFixture) TestDoReturnsAPIErrorOnErrorStatus() {
+	httpClient := &fakeHttpClient{statusCode: http.StatusConflict, body: "{\"code\":\"ORDER_NOT_OPEN\",\"detail\":\"order is closed\"}"}
+	client := NewBittrexClient("key", "secret", httpClient)
+	body, err := client.Do(context.Background(), "DELETE", "https://api.bittrex.com/v3/orders/fab677a0", "", true)
+	this.So(body, should.BeNil)
+	this.So(errors.Is(err, ErrOrderNotOpen), should.BeTrue)
+
+	var apiError *APIError
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(apiError.StatusCode, should.Equal, http.StatusConflict)
+	this.So(apiError.Detail, should.Equal, "order is closed")
+	this.So(err.Error(), should.Equal, "bittrex 409 ORDER_NOT_OPEN: order is closed")
+}
+
+func (this *BittrexClientFixture) TestDoWithHeaderSurfacesHeaders() {
+	httpClient := &fakeHttpClient{statusCode: http.StatusOK, body: "{}", header: http.Header{"Sequence": {"31337"}}}
+	client := NewBittrexClient("key", "secret", httpClient)
+	body, header, err := client.DoWithHeader(context.Background(), "GET", "https://api.bittrex.com/v3/markets/ETH-BTC/orderbook", "", false)
+	this.So(err, should.BeNil)
+	this.So(string(body), should.Equal, "{}")
+	this.So(header.Get("Sequence"), should.Equal, "31337")
+}
+
+func (this *BittrexClientFixture) TestDoClassifiesStatus() {
+	httpClient := &fakeHttpClient{statusCode: http.StatusNotFound, body: "{\"code\":\"MARKET_DOES_NOT_EXIST\"}"}
+	client := NewBittrexClient("key", "secret", httpClient)
+	_, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets/FAKE-BTC", "", false)
+	var apiError *APIError
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(apiError.ClientError(), should.BeTrue)
+	this.So(apiError.ServerError(), should.BeFalse)
+
+	httpClient = &fakeHttpClient{statusCode: http.StatusServiceUnavailable, body: "{\"code\":\"THROTTLED\"}", header: http.Header{"Retry-After": {"5"}}}
+	client = NewBittrexClient("key", "secret", httpClient)
+	_, err = client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(apiError.ServerError(), should.BeTrue)
+	this.So(apiError.Header.Get("Retry-After"), should.Equal, "5")
+}
+
+func (this *BittrexClientFixture) TestDoRejectsNonJSONBody() {
+	httpClient := &fakeHttpClient{
+		statusCode: http.StatusServiceUnavailable,
+		body:       "<html><body>Bittrex is currently down for maintenance</body></html>",
+		header:     http.Header{"Content-Type": {"text/html"}},
+	}
+	client := NewBittrexClient("key", "secret", httpClient)
+	body, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets/ETH-BTC", "", false)
+	this.So(body, should.BeNil)
+	var nonJSON *NonJSONResponseError
+	this.So(errors.As(err, &nonJSON), should.BeTrue)
+	this.So(nonJSON.ServerError(), should.BeTrue)
+	this.So(err.Error(), should.Equal, "bittrex 503: non-JSON response (text/html)")
+
+	httpClient.statusCode = http.StatusOK
+	body, err = client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets/ETH-BTC", "", false)
+	this.So(body, should.BeNil)
+	this.So(errors.As(err, &nonJSON), should.BeTrue)
+	this.So(nonJSON.ServerError(), should.BeFalse)
+}
+
+func (this *BittrexClientFixture) TestDoPropagatesCancellation() {
+	client := NewBittrexClient("key", "secret", &fakeHttpClient{statusCode: http.StatusOK, body: "{}"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	body, err := client.Do(ctx, "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(body, should.BeNil)
+	this.So(errors.Is(err, context.Canceled), should.BeTrue)
+}
+
+func (this *BittrexClientFixture) TestDoPropagatesDeadline() {
+	client := NewBittrexClient("key", "secret", &fakeHttpClient{statusCode: http.StatusOK, body: "{}"})
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	body, err := client.Do(ctx, "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(body, should.BeNil)
+	this.So(errors.Is(err, context.DeadlineExceeded), should.BeTrue)
+}
+
+func (this *BittrexClientFixture) TestDoRequiresCredentialsToAuthenticate() {
+	client := NewBittrexClient("", "", &fakeHttpClient{statusCode: http.StatusOK})
+	body, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/balances", "", true)
+	this.So(err, should.NotBeNil)
+	this.So(body, should.BeNil)
+}
+
+type fakeHttpClient struct {
+	statusCode int
+	body       string
+	header     http.Header
+}
 
 func (this *fakeHttpClient) Get(url string) (resp *http.Response, err error) {
 	return &http.Response{Body: http.NoBody}, nil
@@ -32,5 +137,12 @@ func (this *fakeHttpClient) Post(url, contentType string, body io.Reader) (resp
 	return &http.Response{}, nil
 }
 func (this *fakeHttpClient) Do(req *http.Request) (*http.Response, error) {
-	return &http.Response{}, nil
+	if err := req.Context().Err(); err != nil {
+		return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: err}
+	}
+	return &http.Response{
+		StatusCode: this.statusCode,
+		Header:     this.header,
+		Body:       ioutil.NopCloser(strings.NewReader(this.body)),
+	}, nil
 }
@@ -0,0 +1,115 @@
+package bittrex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+//Pings taken per sync, the one with the shortest round trip gives the offset
+const clockSyncSamples = 3
+
+//Difference between the Bittrex clock and the local one, added to the local time when signing Api-Timestamp
+type ClockOffset struct {
+	Offset   time.Duration //Server time minus local time, positive when the local clock is behind
+	RTT      time.Duration //Round trip of the ping the offset was derived from
+	Measured time.Time     //Local time of the measurement, zero until the first successful sync
+}
+
+type serverTime struct {
+	ServerTime int64 `json:"serverTime"`
+}
+
+func (this *BittrexAPI) Ping() (time.Time, error) {
+	return this.PingCtx(context.Background())
+}
+
+//Returns the server time, with millisecond precision
+func (this *BittrexAPI) PingCtx(ctx context.Context) (time.Time, error) {
+	uri := this.uri + "/ping"
+	body, err := this.client.Do(ctx, "GET", uri, "", false)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseServerTime(body)
+}
+
+func parseServerTime(body []byte) (time.Time, error) {
+	response := serverTime{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return time.Time{}, err
+	}
+	if response.ServerTime <= 0 {
+		return time.Time{}, errors.New("ping response carries no server time")
+	}
+	return time.Unix(0, response.ServerTime*int64(time.Millisecond)), nil
+}
+
+//Offset currently applied to Api-Timestamp, alert on its size to catch a drifting host
+func (this *bittrexClient) ClockOffset() ClockOffset {
+	this.clockMutex.RLock()
+	defer this.clockMutex.RUnlock()
+	return this.clock
+}
+
+//Measures the offset against baseURI + "/ping", assuming the server read its clock halfway through the round trip,
+//and applies it to the requests signed from then on
+func (this *bittrexClient) SyncClock(ctx context.Context, baseURI string) (ClockOffset, error) {
+	var best ClockOffset
+	for i := 0; i < clockSyncSamples; i++ {
+		sent := this.now()
+		body, _, err := this.send(ctx, "GET", baseURI+"/ping", "", false)
+		received := this.now()
+		if err != nil {
+			return this.ClockOffset(), err
+		}
+		server, err := parseServerTime(body)
+		if err != nil {
+			return this.ClockOffset(), err
+		}
+
+		rtt := received.Sub(sent)
+		if i == 0 || rtt < best.RTT {
+			best = ClockOffset{Offset: server.Sub(sent.Add(rtt / 2)), RTT: rtt, Measured: received}
+		}
+	}
+
+	this.clockMutex.Lock()
+	this.clock = best
+	this.clockMutex.Unlock()
+	return best, nil
+}
+
+//Syncs now, then every interval until ctx is done. Only the first sync reports its error, a later failure keeps
+//the previous offset, so watch ClockOffset().Measured to notice when syncing stopped working.
+func (this *bittrexClient) StartClockSync(ctx context.Context, baseURI string, interval time.Duration) error {
+	if _, err := this.SyncClock(ctx, baseURI); err != nil {
+		return err
+	}
+
+	go func() {
+		ticks, stop := this.ticker(interval)
+		defer stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticks:
+				this.SyncClock(ctx, baseURI)
+			}
+		}
+	}()
+	return nil
+}
+
+func newTicker(interval time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(interval)
+	return ticker.C, ticker.Stop
+}
+
+//Local time corrected by the last measured offset
+func (this *bittrexClient) serverNow() time.Time {
+	return this.now().Add(this.ClockOffset().Offset)
+}
@@ -0,0 +1,155 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestClockFixture(t *testing.T) {
+	gunit.Run(new(ClockFixture), t)
+}
+
+type ClockFixture struct {
+	*gunit.Fixture
+	http   *scriptedHttpClient
+	client *bittrexClient
+	ticks  []time.Time
+}
+
+func (this *ClockFixture) Setup() {
+	this.http = &scriptedHttpClient{}
+	this.client = NewBittrexClient("key", "secret", this.http)
+	this.ticks = nil
+	this.client.now = func() time.Time {
+		next := this.ticks[0]
+		if len(this.ticks) > 1 {
+			this.ticks = this.ticks[1:]
+		}
+		return next
+	}
+}
+
+func (this *ClockFixture) ping(serverTime time.Time) {
+	this.http.respond(http.StatusOK, "{\"serverTime\": "+strconv.FormatInt(serverTime.UnixNano()/int64(time.Millisecond), 10)+"}", nil)
+}
+
+func (this *ClockFixture) TestPing() {
+	client := &recordingClient{responses: map[string]string{"/ping": "{\"serverTime\": 1601553600123}"}}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.Ping()
+	this.So(err, should.BeNil)
+	this.So(result.Equal(time.Date(2020, 10, 1, 12, 0, 0, 123000000, time.UTC)), should.BeTrue)
+}
+
+func (this *ClockFixture) TestPingWithoutServerTime() {
+	client := &recordingClient{responses: map[string]string{"/ping": "{}"}}
+	bittrex := NewBittrexAPI(client, "")
+	_, err := bittrex.Ping()
+	this.So(err, should.NotBeNil)
+}
+
+func (this *ClockFixture) TestSyncClockKeepsShortestRoundTrip() {
+	local := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	this.ticks = []time.Time{
+		local, local.Add(400 * time.Millisecond),
+		local.Add(time.Second), local.Add(time.Second + 100*time.Millisecond),
+		local.Add(2 * time.Second), local.Add(2*time.Second + 300*time.Millisecond),
+	}
+	this.ping(local.Add(3*time.Second + 200*time.Millisecond))
+	this.ping(local.Add(4*time.Second + 50*time.Millisecond))
+	this.ping(local.Add(5*time.Second + 150*time.Millisecond))
+
+	offset, err := this.client.SyncClock(context.Background(), "https://api.bittrex.com/v3")
+	this.So(err, should.BeNil)
+	this.So(offset.Offset, should.Equal, 3*time.Second)
+	this.So(offset.RTT, should.Equal, 100*time.Millisecond)
+	this.So(offset.Measured, should.Equal, local.Add(time.Second+100*time.Millisecond))
+	this.So(this.client.ClockOffset(), should.Resemble, offset)
+	this.So(this.http.requests, should.Resemble, []string{"GET /v3/ping", "GET /v3/ping", "GET /v3/ping"})
+}
+
+func (this *ClockFixture) TestSignsWithServerTime() {
+	local := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	this.ticks = []time.Time{local}
+	this.ping(local.Add(-2 * time.Second))
+	this.ping(local.Add(-2 * time.Second))
+	this.ping(local.Add(-2 * time.Second))
+	_, err := this.client.SyncClock(context.Background(), "https://api.bittrex.com/v3")
+	this.So(err, should.BeNil)
+
+	this.http.respond(http.StatusOK, "[]", nil)
+	_, err = this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/balances", "", true)
+	this.So(err, should.BeNil)
+	this.So(this.http.last.Header.Get("Api-Timestamp"), should.Equal, strconv.FormatInt(local.Add(-2*time.Second).UnixNano()/int64(time.Millisecond), 10))
+}
+
+func (this *ClockFixture) TestFailedSyncKeepsPreviousOffset() {
+	local := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	this.ticks = []time.Time{local}
+	this.ping(local.Add(time.Second))
+	this.ping(local.Add(time.Second))
+	this.ping(local.Add(time.Second))
+	_, err := this.client.SyncClock(context.Background(), "https://api.bittrex.com/v3")
+	this.So(err, should.BeNil)
+
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	offset, err := this.client.SyncClock(context.Background(), "https://api.bittrex.com/v3")
+	var apiError *APIError
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(offset.Offset, should.Equal, time.Second)
+	this.So(this.client.ClockOffset().Offset, should.Equal, time.Second)
+}
+
+func (this *ClockFixture) TestStartClockSyncResyncsOnEveryTick() {
+	local := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	this.ticks = []time.Time{local}
+	ticks := make(chan time.Time)
+	stopped := make(chan struct{})
+	var interval time.Duration
+	this.client.ticker = func(d time.Duration) (<-chan time.Time, func()) {
+		interval = d
+		return ticks, func() { close(stopped) }
+	}
+	for i := 0; i < clockSyncSamples; i++ {
+		this.ping(local.Add(time.Second))
+	}
+	for i := 0; i < clockSyncSamples; i++ {
+		this.ping(local.Add(3 * time.Second))
+	}
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := this.client.StartClockSync(ctx, "https://api.bittrex.com/v3", time.Minute)
+	this.So(err, should.BeNil)
+	this.So(this.client.ClockOffset().Offset, should.Equal, time.Second)
+
+	ticks <- local
+	ticks <- local //Received once the first tick's sync is done, its own sync fails
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		this.Error("clock sync still running after ctx was cancelled")
+	}
+	this.So(interval, should.Equal, time.Minute)
+	this.So(this.client.ClockOffset().Offset, should.Equal, 3*time.Second)
+	this.So(len(this.http.requests), should.Equal, 2*clockSyncSamples+1)
+}
+
+func (this *ClockFixture) TestStartClockSyncReportsFirstError() {
+	this.ticks = []time.Time{time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)}
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+
+	err := this.client.StartClockSync(context.Background(), "https://api.bittrex.com/v3", time.Minute)
+	var apiError *APIError
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(this.client.ClockOffset(), should.Resemble, ClockOffset{})
+}
@@ -0,0 +1,151 @@
+package bittrex
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Credentials struct {
+	APIKey    string `json:"apiKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+//Signs with the HMAC-SHA512 scheme of https://bittrex.github.io/api/v3#topic-Authentication,
+//covering the Api-Subaccount-Id header when the request carries one
+type HMACSigner struct {
+	credentials CredentialsProvider
+}
+
+func NewHMACSigner(credentials CredentialsProvider) *HMACSigner {
+	return &HMACSigner{credentials: credentials}
+}
+
+func (this *HMACSigner) Sign(ctx context.Context, request *http.Request, payload string, timestamp time.Time) error {
+	credentials, err := this.credentials.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+	if len(credentials.APIKey) == 0 || len(credentials.SecretKey) == 0 {
+		return errors.New("you need to set API Key and API Secret to call this method")
+	}
+
+	milliseconds := strconv.FormatInt(timestamp.UnixNano()/1000000, 10)
+	query := request.URL.Query()
+	request.URL.RawQuery = query.Encode()
+
+	hash := sha512.New()
+	hash.Write([]byte(payload))
+	contentHash := hex.EncodeToString(hash.Sum(nil))
+
+	preSigned := strings.Join([]string{milliseconds, request.URL.String(), request.Method, contentHash, request.Header.Get("Api-Subaccount-Id")}, "")
+	sigHash := hmac.New(sha512.New, []byte(credentials.SecretKey))
+	sigHash.Write([]byte(preSigned))
+	signature := hex.EncodeToString(sigHash.Sum(nil))
+
+	request.Header.Add("Api-Key", credentials.APIKey)
+	request.Header.Add("Api-Timestamp", milliseconds)
+	request.Header.Add("Api-Content-Hash", contentHash)
+	request.Header.Add("Api-Signature", signature)
+	return nil
+}
+
+//Fixed credentials, as passed to NewBittrexClient
+type StaticCredentials Credentials
+
+func (this StaticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials(this), nil
+}
+
+//Adapts a function, e.g. one decrypting a keystore, to CredentialsProvider
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
+func (this CredentialsFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return this(ctx)
+}
+
+//Reads the keys from the environment on every request, BITTREX_API_KEY and BITTREX_SECRET_KEY unless named otherwise
+type EnvCredentials struct {
+	APIKeyVariable    string
+	SecretKeyVariable string
+}
+
+func (this EnvCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	apiKeyVariable, secretKeyVariable := this.APIKeyVariable, this.SecretKeyVariable
+	if apiKeyVariable == "" {
+		apiKeyVariable = "BITTREX_API_KEY"
+	}
+	if secretKeyVariable == "" {
+		secretKeyVariable = "BITTREX_SECRET_KEY"
+	}
+	return Credentials{APIKey: os.Getenv(apiKeyVariable), SecretKey: os.Getenv(secretKeyVariable)}, nil
+}
+
+//Reads {"apiKey": "...", "secretKey": "..."} from a JSON file, again whenever the file is modified
+type FileCredentials struct {
+	path     string
+	mutex    sync.Mutex
+	modified time.Time
+	cached   Credentials
+}
+
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+func (this *FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	info, err := os.Stat(this.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if info.ModTime().Equal(this.modified) {
+		return this.cached, nil
+	}
+
+	content, err := ioutil.ReadFile(this.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	credentials := Credentials{}
+	if err := json.Unmarshal(content, &credentials); err != nil {
+		return Credentials{}, err
+	}
+	this.cached, this.modified = credentials, info.ModTime()
+	return credentials, nil
+}
+
+//Holds credentials replaced at runtime, e.g. by a goroutine renewing them from a vault.
+//Requests signed after Rotate returns use the new keys.
+type RotatingCredentials struct {
+	mutex   sync.RWMutex
+	current Credentials
+}
+
+func NewRotatingCredentials(initial Credentials) *RotatingCredentials {
+	return &RotatingCredentials{current: initial}
+}
+
+func (this *RotatingCredentials) Rotate(credentials Credentials) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.current = credentials
+}
+
+func (this *RotatingCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return this.current, nil
+}
@@ -0,0 +1,109 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestCredentialsFixture(t *testing.T) {
+	gunit.Run(new(CredentialsFixture), t)
+}
+
+type CredentialsFixture struct {
+	*gunit.Fixture
+}
+
+func (this *CredentialsFixture) TestHMACSigner() {
+	payload := "{\"currencySymbol\":\"BTC\"}"
+	request, _ := http.NewRequest("POST", "https://api.bittrex.com/v3/addresses", strings.NewReader(payload))
+	signer := NewHMACSigner(StaticCredentials{APIKey: "key", SecretKey: "secret"})
+	err := signer.Sign(context.Background(), request, payload, time.Unix(0, 1601553600123*int64(time.Millisecond)))
+	this.So(err, should.BeNil)
+	this.So(request.Header.Get("Api-Key"), should.Equal, "key")
+	this.So(request.Header.Get("Api-Timestamp"), should.Equal, "1601553600123")
+	this.So(request.Header.Get("Api-Content-Hash"), should.Equal, "18aa13a42b5efe3c45b37050626f23fcc4f3b4acf5e479f2f50212722cdcd80baa0ba6ddf8dcbd0f1b88008aaf9ec33874e4be9ab6ec2dece611c2dbdca87560")
+	this.So(request.Header.Get("Api-Signature"), should.Equal, "a91c55fdb26db27341de81d012184bbf282ec44602e60704f3ae73fd346b43c4863841dac02368fd3c1e5d15ebc8e3c8c5ffa2263914856b30923ed5116aa2da")
+}
+
+func (this *CredentialsFixture) TestMissingCredentials() {
+	request, _ := http.NewRequest("GET", "https://api.bittrex.com/v3/balances", nil)
+	err := NewHMACSigner(StaticCredentials{APIKey: "key"}).Sign(context.Background(), request, "", time.Now())
+	this.So(err, should.NotBeNil)
+	this.So(request.Header.Get("Api-Signature"), should.BeEmpty)
+}
+
+func (this *CredentialsFixture) TestProviderErrorStopsRequest() {
+	httpClient := &scriptedHttpClient{}
+	failure := errors.New("keystore locked")
+	signer := NewHMACSigner(CredentialsFunc(func(ctx context.Context) (Credentials, error) {
+		return Credentials{}, failure
+	}))
+	client := NewBittrexClientWithSigner(signer, httpClient)
+	_, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/balances", "", true)
+	this.So(err, should.Equal, failure)
+	this.So(httpClient.requests, should.BeEmpty)
+}
+
+func (this *CredentialsFixture) TestRotatingCredentials() {
+	httpClient := &scriptedHttpClient{}
+	credentials := NewRotatingCredentials(Credentials{APIKey: "old", SecretKey: "secret"})
+	client := NewBittrexClientWithSigner(NewHMACSigner(credentials), httpClient)
+
+	httpClient.respond(http.StatusOK, "[]", nil)
+	_, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/balances", "", true)
+	this.So(err, should.BeNil)
+	this.So(httpClient.last.Header.Get("Api-Key"), should.Equal, "old")
+
+	credentials.Rotate(Credentials{APIKey: "new", SecretKey: "secret"})
+	httpClient.respond(http.StatusOK, "[]", nil)
+	_, err = client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/balances", "", true)
+	this.So(err, should.BeNil)
+	this.So(httpClient.last.Header.Get("Api-Key"), should.Equal, "new")
+}
+
+func (this *CredentialsFixture) TestEnvCredentials() {
+	os.Setenv("TEST_BITTREX_API_KEY", "key")
+	os.Setenv("TEST_BITTREX_SECRET_KEY", "secret")
+	defer os.Unsetenv("TEST_BITTREX_API_KEY")
+	defer os.Unsetenv("TEST_BITTREX_SECRET_KEY")
+
+	provider := EnvCredentials{APIKeyVariable: "TEST_BITTREX_API_KEY", SecretKeyVariable: "TEST_BITTREX_SECRET_KEY"}
+	credentials, err := provider.Credentials(context.Background())
+	this.So(err, should.BeNil)
+	this.So(credentials, should.Resemble, Credentials{APIKey: "key", SecretKey: "secret"})
+}
+
+func (this *CredentialsFixture) TestFileCredentialsReloadWhenModified() {
+	dir, err := ioutil.TempDir("", "bittrex")
+	this.So(err, should.BeNil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "credentials.json")
+
+	ioutil.WriteFile(path, []byte("{\"apiKey\": \"old\",\"secretKey\": \"secret\"}"), 0600)
+	provider := NewFileCredentials(path)
+	credentials, err := provider.Credentials(context.Background())
+	this.So(err, should.BeNil)
+	this.So(credentials.APIKey, should.Equal, "old")
+
+	ioutil.WriteFile(path, []byte("{\"apiKey\": \"new\",\"secretKey\": \"secret\"}"), 0600)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	credentials, err = provider.Credentials(context.Background())
+	this.So(err, should.BeNil)
+	this.So(credentials.APIKey, should.Equal, "new")
+}
+
+func (this *CredentialsFixture) TestMissingFile() {
+	_, err := NewFileCredentials(filepath.Join(os.TempDir(), "does-not-exist.json")).Credentials(context.Background())
+	this.So(err, should.NotBeNil)
+}
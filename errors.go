@@ -0,0 +1,115 @@
+package bittrex
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+//Error returned by the Bittrex API, see https://bittrex.github.io/api/v3#error-codes
+//Use errors.Is with the Err* values below to match on the code, or errors.As to read the status and detail.
+type APIError struct {
+	StatusCode int             `json:"-"` //HTTP status, zero for errors detected before the request was sent
+	Header     http.Header     `json:"-"` //Response headers, e.g. Retry-After
+	Code       string          `json:"code"`
+	Detail     string          `json:"detail"`
+	Data       json.RawMessage `json:"data"`
+}
+
+var (
+	ErrAccountDisabled                  = &APIError{Code: "ACCOUNT_DISABLED"}
+	ErrAPIKeyInvalid                    = &APIError{Code: "APIKEY_INVALID"}
+	ErrBadRequest                       = &APIError{Code: "BAD_REQUEST"}
+	ErrClientOrderIDAlreadyExists       = &APIError{Code: "CLIENTORDERID_ALREADY_EXISTS"}
+	ErrCurrencyDoesNotExist             = &APIError{Code: "CURRENCY_DOES_NOT_EXIST"}
+	ErrCurrencyOffline                  = &APIError{Code: "CURRENCY_OFFLINE"}
+	ErrDustTradeDisallowedMinValue      = &APIError{Code: "DUST_TRADE_DISALLOWED_MIN_VALUE"}
+	ErrInsufficientAwards               = &APIError{Code: "INSUFFICIENT_AWARDS"}
+	ErrInsufficientFunds                = &APIError{Code: "INSUFFICIENT_FUNDS"}
+	ErrInvalidSignature                 = &APIError{Code: "INVALID_SIGNATURE"}
+	ErrInvalidTimestamp                 = &APIError{Code: "INVALID_TIMESTAMP"}
+	ErrMarketDoesNotExist               = &APIError{Code: "MARKET_DOES_NOT_EXIST"}
+	ErrMarketNameReversed               = &APIError{Code: "MARKET_NAME_REVERSED"}
+	ErrMarketOffline                    = &APIError{Code: "MARKET_OFFLINE"}
+	ErrMinTradeRequirementNotMet        = &APIError{Code: "MIN_TRADE_REQUIREMENT_NOT_MET"}
+	ErrNotFound                         = &APIError{Code: "NOT_FOUND"}
+	ErrOrderNotOpen                     = &APIError{Code: "ORDER_NOT_OPEN"}
+	ErrRateLimitExceeded                = &APIError{Code: "RATE_LIMIT_EXCEEDED"}
+	ErrRatePrecisionNotAllowed          = &APIError{Code: "RATE_PRECISION_NOT_ALLOWED"}
+	ErrSubaccountOfSubaccountNotAllowed = &APIError{Code: "SUBACCOUNT_OF_SUBACCOUNT_NOT_ALLOWED"}
+	ErrThrottled                        = &APIError{Code: "THROTTLED"}
+	ErrUnauthorized                     = &APIError{Code: "UNAUTHORIZED"}
+)
+
+func (this *APIError) Error() string {
+	message := "bittrex"
+	if this.StatusCode != 0 {
+		message += " " + strconv.Itoa(this.StatusCode)
+	}
+	if this.Code != "" {
+		message += " " + this.Code
+	}
+	if this.Detail != "" {
+		message += ": " + this.Detail
+	}
+	return message
+}
+
+//4xx, the request has to be changed before it can succeed
+func (this *APIError) ClientError() bool {
+	return this.StatusCode >= 400 && this.StatusCode < 500
+}
+
+//5xx, the same request may succeed later
+func (this *APIError) ServerError() bool {
+	return this.StatusCode >= 500
+}
+
+//Matches any *APIError carrying the same code, so errors.Is(err, ErrOrderNotOpen) holds whatever the status
+func (this *APIError) Is(target error) bool {
+	other, ok := target.(*APIError)
+	return ok && other.Code != "" && other.Code == this.Code
+}
+
+func newAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	apiError := &APIError{}
+	if err := json.Unmarshal(body, apiError); err != nil {
+		apiError.Detail = string(body)
+	}
+	apiError.StatusCode = statusCode
+	apiError.Header = header
+	return apiError
+}
+
+//Returned whatever the status when the response body is not JSON, typically the HTML page served while
+//Bittrex is under maintenance or by a proxy in front of it
+type NonJSONResponseError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (this *NonJSONResponseError) Error() string {
+	message := "bittrex " + strconv.Itoa(this.StatusCode) + ": non-JSON response"
+	if contentType := this.Header.Get("Content-Type"); contentType != "" {
+		message += " (" + contentType + ")"
+	}
+	return message
+}
+
+//5xx, the same request may succeed later
+func (this *NonJSONResponseError) ServerError() bool {
+	return this.StatusCode >= 500
+}
+
+//Error of one operation in a bulk result, carrying the per order code when there is one.
+//nil when the operation succeeded.
+func bulkResultError(statusCode int, order *Order) error {
+	if order != nil && order.Code != nil {
+		return &APIError{StatusCode: statusCode, Code: *order.Code}
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return &APIError{StatusCode: statusCode}
+	}
+	return nil
+}
@@ -3,17 +3,14 @@ package bittrex
 import (
 	"log"
 	"net/http"
-	"os"
 
 	"github.com/shopspring/decimal"
 )
 
 func example() {
 	uri := "https://api.bittrex.com/v3"
-	apiKey := os.Getenv("BITTREX_API_KEY")
-	secretKey := os.Getenv("BITTREX_SECRET_KEY")
 	httpClient := &http.Client{}
-	client := NewBittrexClient(apiKey, secretKey, httpClient)
+	client := NewBittrexClientWithSigner(NewHMACSigner(EnvCredentials{}), httpClient)
 	api := NewBittrexAPI(client, uri)
 	//log.Println(api.getCurrency("BTC"))
 	//log.Println(api.getBalances())
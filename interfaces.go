@@ -1,8 +1,10 @@
 package bittrex
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"time"
 )
 
 type api interface {
@@ -11,8 +13,18 @@ type api interface {
 }
 
 type Client interface {
-	Do(method, uri, payload string, authenticate bool) ([]byte, error)
-	authenticate(request *http.Request, payload string, uri string, method string) error
+	Do(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, error)
+	DoWithHeader(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, http.Header, error)
+}
+
+//Adds the authentication headers to a request, timestamp is the server time to sign with
+type Signer interface {
+	Sign(ctx context.Context, request *http.Request, payload string, timestamp time.Time) error
+}
+
+//Looked up for every signed request, so keys can be rotated without rebuilding the client
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
 }
 
 type Http interface {
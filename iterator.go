@@ -0,0 +1,224 @@
+package bittrex
+
+import "context"
+
+type PageDirection string
+
+const (
+	PageDirectionNext     PageDirection = "NEXT"     //Toward older items, following nextPageToken
+	PageDirectionPrevious PageDirection = "PREVIOUS" //Toward newer items, following previousPageToken
+)
+
+//Loads one page with the given filter, items in the order Bittrex returns them, newest first
+type PageFetcher func(ctx context.Context, filter PageFilter) ([]interface{}, error)
+
+//Walks a paginated endpoint one page at a time, requesting the next page only once the current one is consumed.
+//
+//	for it.Next() {
+//		process(it.Item())
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//
+//PageDirectionNext starts from filter.NextPageToken, or the newest page, and yields items newest first.
+//PageDirectionPrevious starts from filter.PreviousPageToken and yields items oldest first. Without a token it first
+//pages through the whole window to find its oldest page, requesting every page twice.
+//StartDate and EndDate are sent with every page. Iteration stops at the first error or once ctx is done.
+type PageIterator struct {
+	ctx       context.Context
+	filter    PageFilter
+	direction PageDirection
+	fetch     PageFetcher
+	id        func(item interface{}) string
+	page      []interface{}
+	item      interface{}
+	last      bool
+	err       error
+}
+
+//id returns the token identifying an item, its id for every history endpoint
+func NewPageIterator(ctx context.Context, filter PageFilter, direction PageDirection, fetch PageFetcher, id func(item interface{}) string) *PageIterator {
+	if direction == PageDirectionPrevious {
+		filter.NextPageToken = ""
+	} else {
+		direction = PageDirectionNext
+		filter.PreviousPageToken = ""
+	}
+	return &PageIterator{ctx: ctx, filter: filter, direction: direction, fetch: fetch, id: id}
+}
+
+func (this *PageIterator) Next() bool {
+	this.item = nil
+	if this.err != nil {
+		return false
+	}
+	if err := this.ctx.Err(); err != nil {
+		this.err = err
+		return false
+	}
+
+	if len(this.page) == 0 {
+		if this.last || !this.load() {
+			return false
+		}
+	}
+	this.item, this.page = this.page[0], this.page[1:]
+	return true
+}
+
+func (this *PageIterator) load() bool {
+	var page []interface{}
+	var err error
+	seek := this.direction == PageDirectionPrevious && this.filter.PreviousPageToken == ""
+	if seek {
+		page, err = this.oldestPage()
+	} else {
+		page, err = this.fetch(this.ctx, this.filter)
+	}
+	if err != nil {
+		this.err = err
+		return false
+	}
+	if len(page) == 0 {
+		this.last = true
+		return false
+	}
+	this.last = !seek && this.filter.PageSize > 0 && len(page) < this.filter.PageSize
+
+	if this.direction == PageDirectionPrevious {
+		this.filter.PreviousPageToken = this.id(page[0])
+		for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+			page[i], page[j] = page[j], page[i]
+		}
+	} else {
+		this.filter.NextPageToken = this.id(page[len(page)-1])
+	}
+	this.page = page
+	return true
+}
+
+//Follows nextPageToken to the end of the window, keeping only the last page seen
+func (this *PageIterator) oldestPage() ([]interface{}, error) {
+	filter := this.filter
+	var oldest []interface{}
+	for {
+		if err := this.ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := this.fetch(this.ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return oldest, nil
+		}
+		oldest = page
+		if filter.PageSize > 0 && len(page) < filter.PageSize {
+			return oldest, nil
+		}
+		filter.NextPageToken = this.id(page[len(page)-1])
+	}
+}
+
+//Current item, nil before the first call to Next and once it returns false
+func (this *PageIterator) Item() interface{} {
+	return this.item
+}
+
+//First error met, including ctx.Err(), nil when iteration ran out of items
+func (this *PageIterator) Err() error {
+	return this.err
+}
+
+type OrderIterator struct {
+	*PageIterator
+}
+
+func (this *OrderIterator) Item() Order {
+	order, _ := this.PageIterator.Item().(Order)
+	return order
+}
+
+type DepositIterator struct {
+	*PageIterator
+}
+
+func (this *DepositIterator) Item() Deposit {
+	deposit, _ := this.PageIterator.Item().(Deposit)
+	return deposit
+}
+
+type WithdrawalIterator struct {
+	*PageIterator
+}
+
+func (this *WithdrawalIterator) Item() Withdrawal {
+	withdrawal, _ := this.PageIterator.Item().(Withdrawal)
+	return withdrawal
+}
+
+type ExecutionIterator struct {
+	*PageIterator
+}
+
+func (this *ExecutionIterator) Item() *Execution {
+	execution, _ := this.PageIterator.Item().(*Execution)
+	return execution
+}
+
+func (this *BittrexAPI) IterateClosedOrders(ctx context.Context, filter OrderFilter, direction PageDirection) *OrderIterator {
+	fetch := func(ctx context.Context, page PageFilter) ([]interface{}, error) {
+		filter.PageFilter = page
+		orders, err := this.GetClosedOrdersCtx(ctx, filter)
+		items := make([]interface{}, len(orders))
+		for i, order := range orders {
+			items[i] = order
+		}
+		return items, err
+	}
+	id := func(item interface{}) string { return item.(Order).OrderID }
+	return &OrderIterator{NewPageIterator(ctx, filter.PageFilter, direction, fetch, id)}
+}
+
+func (this *BittrexAPI) IterateClosedDeposits(ctx context.Context, filter DepositFilter, direction PageDirection) *DepositIterator {
+	fetch := func(ctx context.Context, page PageFilter) ([]interface{}, error) {
+		filter.PageFilter = page
+		deposits, err := this.GetClosedDepositsCtx(ctx, filter)
+		items := make([]interface{}, len(deposits))
+		for i, deposit := range deposits {
+			items[i] = deposit
+		}
+		return items, err
+	}
+	id := func(item interface{}) string { return item.(Deposit).ID }
+	return &DepositIterator{NewPageIterator(ctx, filter.PageFilter, direction, fetch, id)}
+}
+
+func (this *BittrexAPI) IterateClosedWithdrawals(ctx context.Context, filter WithdrawalFilter, direction PageDirection) *WithdrawalIterator {
+	fetch := func(ctx context.Context, page PageFilter) ([]interface{}, error) {
+		filter.PageFilter = page
+		withdrawals, err := this.GetClosedWithdrawalsCtx(ctx, filter)
+		items := make([]interface{}, len(withdrawals))
+		for i, withdrawal := range withdrawals {
+			items[i] = withdrawal
+		}
+		return items, err
+	}
+	id := func(item interface{}) string { return item.(Withdrawal).ID }
+	return &WithdrawalIterator{NewPageIterator(ctx, filter.PageFilter, direction, fetch, id)}
+}
+
+func (this *BittrexAPI) IterateExecutions(ctx context.Context, filter ExecutionFilter, direction PageDirection) *ExecutionIterator {
+	fetch := func(ctx context.Context, page PageFilter) ([]interface{}, error) {
+		filter.PageFilter = page
+		executions, err := this.GetExecutionsCtx(ctx, filter)
+		items := make([]interface{}, len(executions))
+		for i, execution := range executions {
+			items[i] = execution
+		}
+		return items, err
+	}
+	id := func(item interface{}) string { return item.(*Execution).ID }
+	return &ExecutionIterator{NewPageIterator(ctx, filter.PageFilter, direction, fetch, id)}
+}
@@ -0,0 +1,133 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestIteratorFixture(t *testing.T) {
+	gunit.Run(new(IteratorFixture), t)
+}
+
+type IteratorFixture struct {
+	*gunit.Fixture
+}
+
+func (this *IteratorFixture) TestWalksNextPages() {
+	client := &recordingClient{responses: map[string]string{
+		"/orders/closed?marketSymbol=ETH-BTC&pageSize=2":                  "[{\"id\": \"o4\"},{\"id\": \"o3\"}]",
+		"/orders/closed?marketSymbol=ETH-BTC&nextPageToken=o3&pageSize=2": "[{\"id\": \"o2\"},{\"id\": \"o1\"}]",
+		"/orders/closed?marketSymbol=ETH-BTC&nextPageToken=o1&pageSize=2": "[]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	iterator := bittrex.IterateClosedOrders(context.Background(), OrderFilter{MarketSymbol: "ETH-BTC", PageFilter: PageFilter{PageSize: 2}}, PageDirectionNext)
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Item().OrderID)
+	}
+	this.So(iterator.Err(), should.BeNil)
+	this.So(ids, should.Resemble, []string{"o4", "o3", "o2", "o1"})
+	this.So(len(client.requests), should.Equal, 3)
+}
+
+func (this *IteratorFixture) TestStopsAfterShortPage() {
+	client := &recordingClient{responses: map[string]string{
+		"/deposits/closed?endDate=2020-10-01T00%3A00%3A00Z&pageSize=2&startDate=2020-09-01T00%3A00%3A00Z":                  "[{\"id\": \"d3\"},{\"id\": \"d2\"}]",
+		"/deposits/closed?endDate=2020-10-01T00%3A00%3A00Z&nextPageToken=d2&pageSize=2&startDate=2020-09-01T00%3A00%3A00Z": "[{\"id\": \"d1\"}]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	filter := DepositFilter{PageFilter: PageFilter{
+		PageSize:  2,
+		StartDate: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	iterator := bittrex.IterateClosedDeposits(context.Background(), filter, PageDirectionNext)
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Item().ID)
+	}
+	this.So(iterator.Err(), should.BeNil)
+	this.So(ids, should.Resemble, []string{"d3", "d2", "d1"})
+	this.So(len(client.requests), should.Equal, 2)
+}
+
+func (this *IteratorFixture) TestWalksPreviousPagesOldestFirst() {
+	client := &recordingClient{responses: map[string]string{
+		"/executions?pageSize=2&previousPageToken=e1": "[{\"id\": \"e3\"},{\"id\": \"e2\"}]",
+		"/executions?pageSize=2&previousPageToken=e3": "[{\"id\": \"e4\"}]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	filter := ExecutionFilter{PageFilter: PageFilter{PageSize: 2, PreviousPageToken: "e1", NextPageToken: "ignored"}}
+	iterator := bittrex.IterateExecutions(context.Background(), filter, PageDirectionPrevious)
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Item().ID)
+	}
+	this.So(iterator.Err(), should.BeNil)
+	this.So(ids, should.Resemble, []string{"e2", "e3", "e4"})
+}
+
+func (this *IteratorFixture) TestWalksWindowOldestFirstWithoutToken() {
+	client := &recordingClient{responses: map[string]string{
+		"/executions?endDate=2020-10-01T00%3A00%3A00Z&pageSize=2&startDate=2020-09-01T00%3A00%3A00Z":                      "[{\"id\": \"e5\"},{\"id\": \"e4\"}]",
+		"/executions?endDate=2020-10-01T00%3A00%3A00Z&nextPageToken=e4&pageSize=2&startDate=2020-09-01T00%3A00%3A00Z":     "[{\"id\": \"e3\"},{\"id\": \"e2\"}]",
+		"/executions?endDate=2020-10-01T00%3A00%3A00Z&nextPageToken=e2&pageSize=2&startDate=2020-09-01T00%3A00%3A00Z":     "[{\"id\": \"e1\"}]",
+		"/executions?endDate=2020-10-01T00%3A00%3A00Z&pageSize=2&previousPageToken=e1&startDate=2020-09-01T00%3A00%3A00Z": "[{\"id\": \"e3\"},{\"id\": \"e2\"}]",
+		"/executions?endDate=2020-10-01T00%3A00%3A00Z&pageSize=2&previousPageToken=e3&startDate=2020-09-01T00%3A00%3A00Z": "[{\"id\": \"e5\"},{\"id\": \"e4\"}]",
+		"/executions?endDate=2020-10-01T00%3A00%3A00Z&pageSize=2&previousPageToken=e5&startDate=2020-09-01T00%3A00%3A00Z": "[]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	filter := ExecutionFilter{PageFilter: PageFilter{
+		PageSize:  2,
+		StartDate: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	iterator := bittrex.IterateExecutions(context.Background(), filter, PageDirectionPrevious)
+
+	var ids []string
+	for iterator.Next() {
+		ids = append(ids, iterator.Item().ID)
+	}
+	this.So(iterator.Err(), should.BeNil)
+	this.So(ids, should.Resemble, []string{"e1", "e2", "e3", "e4", "e5"})
+	this.So(len(client.requests), should.Equal, 6)
+}
+
+func (this *IteratorFixture) TestStopsOnError() {
+	client := &recordingClient{responses: map[string]string{
+		"/withdrawals/closed?pageSize=1": "[{\"id\": \"w2\"}]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	iterator := bittrex.IterateClosedWithdrawals(context.Background(), WithdrawalFilter{PageFilter: PageFilter{PageSize: 1}}, PageDirectionNext)
+
+	this.So(iterator.Next(), should.BeTrue)
+	this.So(iterator.Item().ID, should.Equal, "w2")
+	this.So(iterator.Next(), should.BeFalse)
+	this.So(iterator.Err(), should.NotBeNil)
+	this.So(iterator.Item(), should.Resemble, Withdrawal{})
+	this.So(iterator.Next(), should.BeFalse)
+	this.So(len(client.requests), should.Equal, 2)
+}
+
+func (this *IteratorFixture) TestStopsWhenCancelled() {
+	client := &recordingClient{responses: map[string]string{
+		"/orders/closed": "[{\"id\": \"o2\"},{\"id\": \"o1\"}]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	iterator := bittrex.IterateClosedOrders(ctx, OrderFilter{}, PageDirectionNext)
+
+	this.So(iterator.Next(), should.BeTrue)
+	cancel()
+	this.So(iterator.Next(), should.BeFalse)
+	this.So(errors.Is(iterator.Err(), context.Canceled), should.BeTrue)
+	this.So(len(client.requests), should.Equal, 1)
+}
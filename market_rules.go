@@ -0,0 +1,128 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+//Bittrex accepts quantities with up to 8 decimals on every market
+const quantityPrecision int32 = 8
+
+//Rounds a rate to the market precision without crossing the requested price: buy rates are rounded down and
+//sell rates up
+func (this Market) RoundRate(rate decimal.Decimal, direction OrderSide) decimal.Decimal {
+	if direction == OrderSideSell {
+		return rate.Shift(this.Precision).Ceil().Shift(-this.Precision)
+	}
+	return rate.Truncate(this.Precision)
+}
+
+func (this Market) TruncateQuantity(quantity decimal.Decimal) decimal.Decimal {
+	return quantity.Truncate(quantityPrecision)
+}
+
+func (this Market) MinTradeSizeDecimal() (decimal.Decimal, error) {
+	return decimal.NewFromString(this.MinTradeSize)
+}
+
+func (this Market) CheckMinTradeSize(quantity decimal.Decimal) error {
+	minTradeSize, err := this.MinTradeSizeDecimal()
+	if err != nil {
+		return err
+	}
+	if quantity.LessThan(minTradeSize) {
+		detail := fmt.Sprintf("quantity %s is below the %s minimum trade size of %s", quantity, this.Symbol, minTradeSize)
+		return &APIError{Code: ErrMinTradeRequirementNotMet.Code, Detail: detail}
+	}
+	return nil
+}
+
+//Returns a copy of the order with its rates rounded to the market precision and its quantity truncated to the
+//allowed step, or an error when the resulting quantity is below the minimum trade size
+func (this Market) NormalizeOrder(order Order) (Order, error) {
+	if order.MarketSymbol != this.Symbol {
+		return Order{}, errors.New("order for " + order.MarketSymbol + " cannot be normalized against " + this.Symbol)
+	}
+
+	if order.Limit != nil {
+		limit := this.RoundRate(*order.Limit, order.Direction)
+		order.Limit = &limit
+	}
+	if order.Ceiling != nil {
+		ceiling := order.Ceiling.Truncate(this.Precision)
+		order.Ceiling = &ceiling
+	}
+	if order.Quantity != nil {
+		quantity := this.TruncateQuantity(*order.Quantity)
+		if err := this.CheckMinTradeSize(quantity); err != nil {
+			return Order{}, err
+		}
+		order.Quantity = &quantity
+	}
+
+	return order, nil
+}
+
+//Makes CreateOrder normalize every order against the market rules, which are fetched with GetMarkets and
+//cached for ttl. Safe to call while orders are being created, and shared with the copies made by WithSubaccount
+func (this *BittrexAPI) EnableOrderNormalization(ttl time.Duration) {
+	this.markets.store(&marketCache{ttl: ttl, fetch: this.GetMarketsCtx})
+}
+
+func (this *BittrexAPI) DisableOrderNormalization() {
+	this.markets.store(nil)
+}
+
+//Holds the market cache used by CreateOrder, nil while normalization is disabled
+type marketRules struct {
+	mutex sync.RWMutex
+	cache *marketCache
+}
+
+func (this *marketRules) load() *marketCache {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return this.cache
+}
+
+func (this *marketRules) store(cache *marketCache) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.cache = cache
+}
+
+type marketCache struct {
+	mutex     sync.Mutex
+	ttl       time.Duration
+	fetch     func(ctx context.Context) ([]Market, error)
+	fetchedAt time.Time
+	markets   map[string]Market
+}
+
+func (this *marketCache) get(ctx context.Context, symbol string) (Market, error) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.markets == nil || time.Since(this.fetchedAt) > this.ttl {
+		markets, err := this.fetch(ctx)
+		if err != nil {
+			return Market{}, err
+		}
+		this.markets = make(map[string]Market, len(markets))
+		for _, market := range markets {
+			this.markets[market.Symbol] = market
+		}
+		this.fetchedAt = time.Now()
+	}
+
+	market, ok := this.markets[symbol]
+	if !ok {
+		return Market{}, errors.New("unknown market " + symbol)
+	}
+	return market, nil
+}
@@ -0,0 +1,137 @@
+package bittrex
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestMarketRulesFixture(t *testing.T) {
+	gunit.Run(new(MarketRulesFixture), t)
+}
+
+type MarketRulesFixture struct {
+	*gunit.Fixture
+	market Market
+}
+
+func (this *MarketRulesFixture) Setup() {
+	this.market = Market{Symbol: "4ART-USDT", MinTradeSize: "10.00000000", Precision: 5}
+}
+
+func (this *MarketRulesFixture) TestRoundRate() {
+	rate := decimal.NewFromFloat(0.0123456)
+	this.So(this.market.RoundRate(rate, OrderSideBuy), should.Resemble, decimal.NewFromFloat(0.01234))
+	this.So(this.market.RoundRate(rate, OrderSideSell), should.Resemble, decimal.NewFromFloat(0.01235))
+	this.So(this.market.RoundRate(decimal.NewFromFloat(0.01234), OrderSideSell), should.Resemble, decimal.NewFromFloat(0.01234))
+}
+
+func (this *MarketRulesFixture) TestTruncateQuantity() {
+	this.So(this.market.TruncateQuantity(decimal.NewFromFloat(12.123456789)), should.Resemble, decimal.NewFromFloat(12.12345678))
+}
+
+func (this *MarketRulesFixture) TestCheckMinTradeSize() {
+	this.So(this.market.CheckMinTradeSize(decimal.NewFromFloat(10)), should.BeNil)
+	this.So(errors.Is(this.market.CheckMinTradeSize(decimal.NewFromFloat(9.99999999)), ErrMinTradeRequirementNotMet), should.BeTrue)
+	this.So(Market{MinTradeSize: "bad"}.CheckMinTradeSize(decimal.NewFromFloat(10)), should.NotBeNil)
+
+	var apiError *APIError
+	this.So(errors.As(this.market.CheckMinTradeSize(decimal.NewFromFloat(9.99999999)), &apiError), should.BeTrue)
+	this.So(apiError, should.NotPointTo, ErrMinTradeRequirementNotMet)
+	this.So(apiError.Detail, should.ContainSubstring, "below the 4ART-USDT minimum trade size")
+}
+
+func (this *MarketRulesFixture) TestNormalizeOrder() {
+	quantity := decimal.NewFromFloat(12.123456789)
+	limit := decimal.NewFromFloat(0.0123456)
+	order, err := this.market.NormalizeOrder(Order{
+		MarketSymbol: "4ART-USDT",
+		Direction:    OrderSideBuy,
+		OrderType:    OrderTypeLimit,
+		Quantity:     &quantity,
+		Limit:        &limit,
+	})
+	this.So(err, should.BeNil)
+	this.So(*order.Quantity, should.Resemble, decimal.NewFromFloat(12.12345678))
+	this.So(*order.Limit, should.Resemble, decimal.NewFromFloat(0.01234))
+	this.So(quantity, should.Resemble, decimal.NewFromFloat(12.123456789))
+}
+
+func (this *MarketRulesFixture) TestNormalizeOrderBelowMinTradeSize() {
+	quantity := decimal.NewFromFloat(9.999999999)
+	_, err := this.market.NormalizeOrder(Order{MarketSymbol: "4ART-USDT", Quantity: &quantity})
+	this.So(err, should.NotBeNil)
+}
+
+func (this *MarketRulesFixture) TestNormalizeOrderOtherMarket() {
+	_, err := this.market.NormalizeOrder(Order{MarketSymbol: "ETH-BTC"})
+	this.So(err, should.NotBeNil)
+}
+
+func (this *MarketRulesFixture) TestCreateOrderNormalization() {
+	client := &recordingClient{fallback: &fakeBittrexClient{}}
+	bittrex := NewBittrexAPI(client, "")
+	bittrex.EnableOrderNormalization(time.Minute)
+	quantity := decimal.NewFromFloat(12.123456789)
+	limit := decimal.NewFromFloat(0.0123456)
+	order := Order{
+		MarketSymbol: "4ART-USDT",
+		Direction:    OrderSideSell,
+		OrderType:    OrderTypeLimit,
+		TimeInForce:  TimeInForceGTC,
+		Quantity:     &quantity,
+		Limit:        &limit,
+	}
+	_, err := bittrex.CreateOrder(order)
+	this.So(err, should.BeNil)
+	_, err = bittrex.CreateOrder(order)
+	this.So(err, should.BeNil)
+	this.So(client.requests, should.Resemble, []string{"/markets", "/orders", "/orders"})
+	this.So(client.payloads[1], should.Equal, "{\"marketSymbol\":\"4ART-USDT\",\"direction\":\"SELL\",\"type\":\"LIMIT\",\"quantity\":\"12.12345678\",\"limit\":\"0.01235\",\"timeInForce\":\"GOOD_TIL_CANCELLED\"}")
+
+	small := decimal.NewFromFloat(1)
+	order.Quantity = &small
+	_, err = bittrex.CreateOrder(order)
+	this.So(err, should.NotBeNil)
+
+	order.MarketSymbol = "UNKNOWN-BTC"
+	_, err = bittrex.CreateOrder(order)
+	this.So(err, should.NotBeNil)
+
+	bittrex.DisableOrderNormalization()
+	order.MarketSymbol = "4ART-USDT"
+	_, err = bittrex.CreateOrder(order)
+	this.So(err, should.BeNil)
+}
+
+func (this *MarketRulesFixture) TestToggleNormalizationWhileCreatingOrders() {
+	bittrex := NewBittrexAPI(&fakeBittrexClient{}, "")
+	scoped := bittrex.WithSubaccount("sub-1")
+	quantity := decimal.NewFromFloat(12.123456789)
+	order := Order{MarketSymbol: "4ART-USDT", Direction: OrderSideBuy, OrderType: OrderTypeMarket, Quantity: &quantity}
+
+	var waiter sync.WaitGroup
+	waiter.Add(1)
+	go func() {
+		defer waiter.Done()
+		for i := 0; i < 100; i++ {
+			bittrex.EnableOrderNormalization(time.Minute)
+			bittrex.DisableOrderNormalization()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		scoped.CreateOrder(order)
+	}
+	waiter.Wait()
+
+	bittrex.EnableOrderNormalization(time.Minute)
+	small := decimal.NewFromFloat(1)
+	order.Quantity = &small
+	_, err := scoped.CreateOrder(order)
+	this.So(err, should.NotBeNil)
+}
@@ -0,0 +1,180 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+//Order to be placed, created by one of the constructors below so that invalid combinations of order type,
+//time in force and amounts are rejected locally instead of by the exchange
+type NewOrderRequest struct {
+	MarketSymbol  string
+	Direction     OrderSide
+	OrderType     OrderType
+	Quantity      *decimal.Decimal
+	Limit         *decimal.Decimal
+	Ceiling       *decimal.Decimal
+	TimeInForce   TimeInForce
+	ClientOrderID string
+	UseAwards     bool
+}
+
+type orderTypeRule struct {
+	quantity    bool
+	limit       bool
+	ceiling     bool
+	buyOnly     bool
+	timeInForce []TimeInForce
+}
+
+var orderTypeRules = map[OrderType]orderTypeRule{
+	OrderTypeLimit: {
+		quantity:    true,
+		limit:       true,
+		timeInForce: []TimeInForce{TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePOGTC},
+	},
+	OrderTypeMarket: {
+		quantity:    true,
+		timeInForce: []TimeInForce{TimeInForceIOC, TimeInForceFOK},
+	},
+	OrderTypeCeilingLimit: {
+		limit:       true,
+		ceiling:     true,
+		buyOnly:     true,
+		timeInForce: []TimeInForce{TimeInForceIOC, TimeInForceFOK},
+	},
+	OrderTypeCeilingMarket: {
+		ceiling:     true,
+		buyOnly:     true,
+		timeInForce: []TimeInForce{TimeInForceIOC, TimeInForceFOK},
+	},
+}
+
+//Good til cancelled limit buy of quantity at no more than limit
+func LimitBuy(marketSymbol string, quantity decimal.Decimal, limit decimal.Decimal) *NewOrderRequest {
+	return &NewOrderRequest{MarketSymbol: marketSymbol, Direction: OrderSideBuy, OrderType: OrderTypeLimit, Quantity: &quantity, Limit: &limit, TimeInForce: TimeInForceGTC}
+}
+
+//Good til cancelled limit sell of quantity at no less than limit
+func LimitSell(marketSymbol string, quantity decimal.Decimal, limit decimal.Decimal) *NewOrderRequest {
+	return &NewOrderRequest{MarketSymbol: marketSymbol, Direction: OrderSideSell, OrderType: OrderTypeLimit, Quantity: &quantity, Limit: &limit, TimeInForce: TimeInForceGTC}
+}
+
+//Immediate or cancel market buy of quantity
+func MarketBuy(marketSymbol string, quantity decimal.Decimal) *NewOrderRequest {
+	return &NewOrderRequest{MarketSymbol: marketSymbol, Direction: OrderSideBuy, OrderType: OrderTypeMarket, Quantity: &quantity, TimeInForce: TimeInForceIOC}
+}
+
+//Immediate or cancel market sell of quantity
+func MarketSell(marketSymbol string, quantity decimal.Decimal) *NewOrderRequest {
+	return &NewOrderRequest{MarketSymbol: marketSymbol, Direction: OrderSideSell, OrderType: OrderTypeMarket, Quantity: &quantity, TimeInForce: TimeInForceIOC}
+}
+
+//Immediate or cancel buy spending up to ceiling in the quote currency at no more than limit
+func CeilingLimitBuy(marketSymbol string, ceiling decimal.Decimal, limit decimal.Decimal) *NewOrderRequest {
+	return &NewOrderRequest{MarketSymbol: marketSymbol, Direction: OrderSideBuy, OrderType: OrderTypeCeilingLimit, Ceiling: &ceiling, Limit: &limit, TimeInForce: TimeInForceIOC}
+}
+
+//Immediate or cancel buy spending up to ceiling in the quote currency
+func CeilingMarketBuy(marketSymbol string, ceiling decimal.Decimal) *NewOrderRequest {
+	return &NewOrderRequest{MarketSymbol: marketSymbol, Direction: OrderSideBuy, OrderType: OrderTypeCeilingMarket, Ceiling: &ceiling, TimeInForce: TimeInForceIOC}
+}
+
+func (this *NewOrderRequest) WithTimeInForce(timeInForce TimeInForce) *NewOrderRequest {
+	this.TimeInForce = timeInForce
+	return this
+}
+
+func (this *NewOrderRequest) WithClientOrderID(clientOrderID string) *NewOrderRequest {
+	this.ClientOrderID = clientOrderID
+	return this
+}
+
+func (this *NewOrderRequest) WithUseAwards(useAwards bool) *NewOrderRequest {
+	this.UseAwards = useAwards
+	return this
+}
+
+func (this *NewOrderRequest) Validate() error {
+	if this.MarketSymbol == "" {
+		return errors.New("order requires a market symbol")
+	}
+	if this.Direction != OrderSideBuy && this.Direction != OrderSideSell {
+		return errors.New("order direction must be BUY or SELL, got " + string(this.Direction))
+	}
+
+	rule, ok := orderTypeRules[this.OrderType]
+	if !ok {
+		return errors.New("unsupported order type " + string(this.OrderType))
+	}
+	if rule.buyOnly && this.Direction != OrderSideBuy {
+		return errors.New(string(this.OrderType) + " orders can only BUY")
+	}
+	if err := validateOrderAmount("quantity", this.OrderType, this.Quantity, rule.quantity); err != nil {
+		return err
+	}
+	if err := validateOrderAmount("limit", this.OrderType, this.Limit, rule.limit); err != nil {
+		return err
+	}
+	if err := validateOrderAmount("ceiling", this.OrderType, this.Ceiling, rule.ceiling); err != nil {
+		return err
+	}
+
+	for _, timeInForce := range rule.timeInForce {
+		if this.TimeInForce == timeInForce {
+			return nil
+		}
+	}
+	return errors.New(string(this.OrderType) + " orders do not support time in force " + string(this.TimeInForce))
+}
+
+func validateOrderAmount(name string, orderType OrderType, amount *decimal.Decimal, required bool) error {
+	if !required {
+		if amount != nil {
+			return errors.New(string(orderType) + " orders must not set a " + name)
+		}
+		return nil
+	}
+	if amount == nil {
+		return errors.New(string(orderType) + " orders require a " + name)
+	}
+	if !amount.IsPositive() {
+		return errors.New("order " + name + " must be positive, got " + amount.String())
+	}
+	return nil
+}
+
+//Validates the request and converts it to the Order accepted by CreateOrder and Batch.CreateOrder
+func (this *NewOrderRequest) Order() (Order, error) {
+	if err := this.Validate(); err != nil {
+		return Order{}, err
+	}
+
+	return Order{
+		MarketSymbol:  this.MarketSymbol,
+		Direction:     this.Direction,
+		OrderType:     this.OrderType,
+		Quantity:      this.Quantity,
+		Limit:         this.Limit,
+		Ceiling:       this.Ceiling,
+		TimeInForce:   this.TimeInForce,
+		ClientOrderId: this.ClientOrderID,
+		UseAwards:     this.UseAwards,
+	}, nil
+}
+
+//Validates the request locally before creating the order
+func (this *BittrexAPI) PlaceOrder(request *NewOrderRequest) (*Order, error) {
+	return this.PlaceOrderCtx(context.Background(), request)
+}
+
+func (this *BittrexAPI) PlaceOrderCtx(ctx context.Context, request *NewOrderRequest) (*Order, error) {
+	order, err := request.Order()
+	if err != nil {
+		return nil, err
+	}
+
+	return this.CreateOrderCtx(ctx, order)
+}
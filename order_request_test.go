@@ -0,0 +1,103 @@
+package bittrex
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestNewOrderRequestFixture(t *testing.T) {
+	gunit.Run(new(NewOrderRequestFixture), t)
+}
+
+type NewOrderRequestFixture struct {
+	*gunit.Fixture
+	quantity decimal.Decimal
+	limit    decimal.Decimal
+	ceiling  decimal.Decimal
+}
+
+func (this *NewOrderRequestFixture) Setup() {
+	this.quantity = decimal.NewFromFloat(5)
+	this.limit = decimal.NewFromFloat(0.00039561)
+	this.ceiling = decimal.NewFromFloat(0.01)
+}
+
+func (this *NewOrderRequestFixture) TestConstructorsAreValid() {
+	this.So(LimitBuy("ETH-BTC", this.quantity, this.limit).Validate(), should.BeNil)
+	this.So(LimitSell("ETH-BTC", this.quantity, this.limit).Validate(), should.BeNil)
+	this.So(MarketBuy("ETH-BTC", this.quantity).Validate(), should.BeNil)
+	this.So(MarketSell("ETH-BTC", this.quantity).Validate(), should.BeNil)
+	this.So(CeilingLimitBuy("ETH-BTC", this.ceiling, this.limit).Validate(), should.BeNil)
+	this.So(CeilingMarketBuy("ETH-BTC", this.ceiling).Validate(), should.BeNil)
+	this.So(LimitBuy("ETH-BTC", this.quantity, this.limit).WithTimeInForce(TimeInForcePOGTC).Validate(), should.BeNil)
+	this.So(MarketSell("ETH-BTC", this.quantity).WithTimeInForce(TimeInForceFOK).Validate(), should.BeNil)
+}
+
+func (this *NewOrderRequestFixture) TestMarketOrderWithLimit() {
+	request := MarketBuy("ETH-BTC", this.quantity)
+	request.Limit = &this.limit
+	this.So(request.Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestPostOnlyMarketOrder() {
+	request := MarketBuy("ETH-BTC", this.quantity).WithTimeInForce(TimeInForcePOGTC)
+	this.So(request.Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestCeilingOrderWithoutCeiling() {
+	request := CeilingMarketBuy("ETH-BTC", this.ceiling)
+	request.Ceiling = nil
+	this.So(request.Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestCeilingOrderWithQuantity() {
+	request := CeilingLimitBuy("ETH-BTC", this.ceiling, this.limit)
+	request.Quantity = &this.quantity
+	this.So(request.Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestCeilingSell() {
+	request := CeilingMarketBuy("ETH-BTC", this.ceiling)
+	request.Direction = OrderSideSell
+	this.So(request.Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestNonPositiveQuantity() {
+	this.So(LimitBuy("ETH-BTC", decimal.Zero, this.limit).Validate(), should.NotBeNil)
+	this.So(MarketSell("ETH-BTC", decimal.NewFromFloat(-1)).Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestMissingMarketDirectionAndType() {
+	this.So(LimitBuy("", this.quantity, this.limit).Validate(), should.NotBeNil)
+	this.So((&NewOrderRequest{MarketSymbol: "ETH-BTC", OrderType: OrderTypeLimit}).Validate(), should.NotBeNil)
+	this.So((&NewOrderRequest{MarketSymbol: "ETH-BTC", Direction: OrderSideBuy, OrderType: "STOP"}).Validate(), should.NotBeNil)
+}
+
+func (this *NewOrderRequestFixture) TestOrder() {
+	order, err := LimitBuy("ETH-BTC", this.quantity, this.limit).WithClientOrderID("c1").WithUseAwards(true).Order()
+	this.So(err, should.BeNil)
+	this.So(order, should.Resemble, Order{
+		MarketSymbol:  "ETH-BTC",
+		Direction:     OrderSideBuy,
+		OrderType:     OrderTypeLimit,
+		Quantity:      &this.quantity,
+		Limit:         &this.limit,
+		TimeInForce:   TimeInForceGTC,
+		ClientOrderId: "c1",
+		UseAwards:     true,
+	})
+}
+
+func (this *NewOrderRequestFixture) TestPlaceOrder() {
+	bittrex := NewBittrexAPI(&fakeBittrexClient{}, "")
+	result, err := bittrex.PlaceOrder(LimitBuy("ETH-BTC", this.quantity, this.limit))
+	this.So(err, should.BeNil)
+	this.So(result.OrderID, should.Equal, "fab677a0-510e-456e-b450-8a75cea69f5d")
+
+	result, err = bittrex.PlaceOrder(MarketBuy("ETH-BTC", this.quantity).WithTimeInForce(TimeInForceGTC))
+	this.So(err, should.NotBeNil)
+	this.So(result, should.BeNil)
+}
@@ -0,0 +1,232 @@
+package bittrex
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Consulted by bittrexClient before every request, including retries. Returns ctx.Err() when ctx is done while
+//waiting for a slot.
+type RateLimiter interface {
+	Acquire(ctx context.Context, method string, uri string, authenticated bool) error
+}
+
+//Every request sent by the client waits on limiter, nil disables client-side rate limiting.
+//Share one client, or one limiter, between the goroutines that should draw on the same budget.
+func (this *bittrexClient) SetRateLimiter(limiter RateLimiter) {
+	this.limiter = limiter
+}
+
+type RateLimitMode string
+
+const (
+	RateLimitBlock    RateLimitMode = "BLOCK"     //Wait until the bucket holds enough tokens
+	RateLimitFailFast RateLimitMode = "FAIL_FAST" //Return a *RateLimitError without sending the request
+)
+
+//Token bucket refilled at RequestsPerSecond up to Burst tokens, a zero rate disables the bucket
+type BucketLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+type RateLimitConfig struct {
+	Public        BucketLimit
+	Authenticated BucketLimit
+	//Tokens taken per request keyed by method and path below /v3, e.g. "GET /orders/closed".
+	//The longest matching path prefix wins, endpoints without a weight take one token.
+	//A weight above the bucket's Burst takes the whole burst, more could never be served.
+	Weights map[string]int
+	Mode    RateLimitMode
+}
+
+//Bittrex allows 60 requests per minute, public and authenticated calls together
+const requestsPerMinute = 60
+
+//Splits the quota evenly between the two buckets, blocking when one runs dry. A full burst plus a minute of refill
+//stays within each half, so no 60 second window carries more than requestsPerMinute requests.
+func DefaultRateLimitConfig() RateLimitConfig {
+	const burst = 5
+	limit := BucketLimit{RequestsPerSecond: float64(requestsPerMinute/2-burst) / 60, Burst: burst}
+	return RateLimitConfig{
+		Public:        limit,
+		Authenticated: limit,
+		Mode:          RateLimitBlock,
+	}
+}
+
+//Returned in RateLimitFailFast mode when the bucket cannot serve the request yet.
+//errors.Is(err, ErrRateLimitExceeded) holds, as it does when Bittrex rejects the request itself.
+type RateLimitError struct {
+	Bucket     string        //"public" or "authenticated"
+	Weight     int           //Tokens the request needed
+	RetryAfter time.Duration //Until the bucket holds enough tokens, assuming no other request takes them
+}
+
+func (this *RateLimitError) Error() string {
+	return "bittrex: " + this.Bucket + " rate limit reached, " + strconv.Itoa(this.Weight) + " tokens available in " + this.RetryAfter.String()
+}
+
+func (this *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimitExceeded
+}
+
+type BucketStats struct {
+	Capacity    int
+	Available   float64
+	Utilization float64 //Share of the capacity in use, above 1 while blocked requests hold tokens in advance
+	Throttled   int64   //Requests that had to wait
+	Rejected    int64   //Requests that failed fast
+}
+
+type RateLimitStats struct {
+	Public        BucketStats
+	Authenticated BucketStats
+}
+
+//RateLimiter safe for concurrent use by every goroutine sharing a client
+type TokenBucketLimiter struct {
+	mutex         sync.Mutex
+	mode          RateLimitMode
+	weights       map[string]int
+	public        *tokenBucket
+	authenticated *tokenBucket
+	now           func() time.Time
+	sleep         func(ctx context.Context, delay time.Duration) error
+}
+
+func NewTokenBucketLimiter(config RateLimitConfig) *TokenBucketLimiter {
+	now := time.Now()
+	weights := make(map[string]int, len(config.Weights))
+	for endpoint, weight := range config.Weights {
+		weights[endpoint] = weight
+	}
+	return &TokenBucketLimiter{
+		mode:          config.Mode,
+		weights:       weights,
+		public:        newTokenBucket("public", config.Public, now),
+		authenticated: newTokenBucket("authenticated", config.Authenticated, now),
+		now:           time.Now,
+		sleep:         sleepContext,
+	}
+}
+
+func (this *TokenBucketLimiter) Acquire(ctx context.Context, method string, uri string, authenticated bool) error {
+	bucket := this.public
+	if authenticated {
+		bucket = this.authenticated
+	}
+	if bucket.limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	weight := this.weight(method, uri)
+	if bucket.limit.Burst > 0 && weight > bucket.limit.Burst {
+		weight = bucket.limit.Burst
+	}
+
+	this.mutex.Lock()
+	bucket.refill(this.now())
+	wait := bucket.wait(weight)
+	if wait > 0 && this.mode == RateLimitFailFast {
+		bucket.rejected++
+		this.mutex.Unlock()
+		return &RateLimitError{Bucket: bucket.name, Weight: weight, RetryAfter: wait}
+	}
+	bucket.tokens -= float64(weight)
+	if wait > 0 {
+		bucket.throttled++
+	}
+	this.mutex.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := this.sleep(ctx, wait); err != nil {
+		this.mutex.Lock()
+		bucket.refill(this.now())
+		bucket.tokens = math.Min(bucket.tokens+float64(weight), float64(bucket.limit.Burst))
+		this.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (this *TokenBucketLimiter) Stats() RateLimitStats {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	now := this.now()
+	this.public.refill(now)
+	this.authenticated.refill(now)
+	return RateLimitStats{Public: this.public.stats(), Authenticated: this.authenticated.stats()}
+}
+
+func (this *TokenBucketLimiter) weight(method string, uri string) int {
+	path := uri
+	if parsed, err := url.Parse(uri); err == nil {
+		path = parsed.Path
+	}
+	if i := strings.Index(path, "/v3/"); i >= 0 {
+		path = path[i+len("/v3"):]
+	}
+
+	weight, matched := 1, -1
+	for endpoint, endpointWeight := range this.weights {
+		parts := strings.SplitN(endpoint, " ", 2)
+		if len(parts) != 2 || parts[0] != method || len(parts[1]) <= matched {
+			continue
+		}
+		prefix := parts[1]
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			weight, matched = endpointWeight, len(prefix)
+		}
+	}
+	return weight
+}
+
+type tokenBucket struct {
+	name      string
+	limit     BucketLimit
+	tokens    float64
+	updated   time.Time
+	throttled int64
+	rejected  int64
+}
+
+func newTokenBucket(name string, limit BucketLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{name: name, limit: limit, tokens: float64(limit.Burst), updated: now}
+}
+
+func (this *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(this.updated); elapsed > 0 {
+		this.tokens += elapsed.Seconds() * this.limit.RequestsPerSecond
+		this.updated = now
+	}
+	if capacity := float64(this.limit.Burst); this.tokens > capacity {
+		this.tokens = capacity
+	}
+}
+
+func (this *tokenBucket) wait(weight int) time.Duration {
+	missing := float64(weight) - this.tokens
+	if missing <= 0 || this.limit.RequestsPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(missing / this.limit.RequestsPerSecond * float64(time.Second))
+}
+
+func (this *tokenBucket) stats() BucketStats {
+	stats := BucketStats{Capacity: this.limit.Burst, Throttled: this.throttled, Rejected: this.rejected}
+	if this.tokens > 0 {
+		stats.Available = this.tokens
+	}
+	if this.limit.Burst > 0 {
+		stats.Utilization = 1 - this.tokens/float64(this.limit.Burst)
+	}
+	return stats
+}
@@ -0,0 +1,192 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestRateLimitFixture(t *testing.T) {
+	gunit.Run(new(RateLimitFixture), t)
+}
+
+type RateLimitFixture struct {
+	*gunit.Fixture
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (this *RateLimitFixture) Setup() {
+	this.now = time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	this.sleeps = nil
+}
+
+func (this *RateLimitFixture) limiter(mode RateLimitMode, weights map[string]int) *TokenBucketLimiter {
+	limiter := NewTokenBucketLimiter(RateLimitConfig{
+		Public:        BucketLimit{RequestsPerSecond: 2, Burst: 4},
+		Authenticated: BucketLimit{RequestsPerSecond: 1, Burst: 2},
+		Weights:       weights,
+		Mode:          mode,
+	})
+	limiter.now = func() time.Time { return this.now }
+	limiter.public.updated = this.now
+	limiter.authenticated.updated = this.now
+	limiter.sleep = func(ctx context.Context, delay time.Duration) error {
+		this.sleeps = append(this.sleeps, delay)
+		this.now = this.now.Add(delay)
+		return ctx.Err()
+	}
+	return limiter
+}
+
+func (this *RateLimitFixture) TestFailsFastOnceBurstIsSpent() {
+	limiter := this.limiter(RateLimitFailFast, nil)
+	ctx := context.Background()
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true), should.BeNil)
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true), should.BeNil)
+
+	err := limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true)
+	this.So(errors.Is(err, ErrRateLimitExceeded), should.BeTrue)
+	var rateLimitError *RateLimitError
+	this.So(errors.As(err, &rateLimitError), should.BeTrue)
+	this.So(rateLimitError.Bucket, should.Equal, "authenticated")
+	this.So(rateLimitError.RetryAfter, should.Equal, time.Second)
+
+	this.now = this.now.Add(time.Second)
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true), should.BeNil)
+}
+
+func (this *RateLimitFixture) TestSeparateBudgets() {
+	limiter := this.limiter(RateLimitFailFast, nil)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/orders/open", true), should.BeNil)
+	}
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/orders/open", true), should.NotBeNil)
+	for i := 0; i < 4; i++ {
+		this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/markets", false), should.BeNil)
+	}
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/markets", false), should.NotBeNil)
+}
+
+func (this *RateLimitFixture) TestEndpointWeights() {
+	limiter := this.limiter(RateLimitFailFast, map[string]int{
+		"GET /markets":         2,
+		"GET /markets/tickers": 3,
+		"POST /markets":        4,
+	})
+	this.So(limiter.weight("GET", "https://api.bittrex.com/v3/markets"), should.Equal, 2)
+	this.So(limiter.weight("GET", "https://api.bittrex.com/v3/markets/ETH-BTC/summary"), should.Equal, 2)
+	this.So(limiter.weight("GET", "https://api.bittrex.com/v3/markets/tickers?x=1"), should.Equal, 3)
+	this.So(limiter.weight("GET", "https://api.bittrex.com/v3/marketsummaries"), should.Equal, 1)
+	this.So(limiter.weight("DELETE", "https://api.bittrex.com/v3/markets"), should.Equal, 1)
+
+	ctx := context.Background()
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/markets/tickers", false), should.BeNil)
+	err := limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/markets", false)
+	var rateLimitError *RateLimitError
+	this.So(errors.As(err, &rateLimitError), should.BeTrue)
+	this.So(rateLimitError.Weight, should.Equal, 2)
+	this.So(rateLimitError.RetryAfter, should.Equal, 500*time.Millisecond)
+}
+
+func (this *RateLimitFixture) TestWeightAboveBurstTakesWholeBurst() {
+	limiter := this.limiter(RateLimitFailFast, map[string]int{"GET /orders/closed": 5})
+	ctx := context.Background()
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/orders/closed", true), should.BeNil)
+
+	err := limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/orders/closed", true)
+	var rateLimitError *RateLimitError
+	this.So(errors.As(err, &rateLimitError), should.BeTrue)
+	this.So(rateLimitError.Weight, should.Equal, 2)
+	this.So(rateLimitError.RetryAfter, should.Equal, 2*time.Second)
+
+	this.now = this.now.Add(2 * time.Second)
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/orders/closed", true), should.BeNil)
+}
+
+func (this *RateLimitFixture) TestBlocksUntilTokensAreAvailable() {
+	limiter := this.limiter(RateLimitBlock, nil)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true), should.BeNil)
+	}
+	this.So(this.sleeps, should.Resemble, []time.Duration{time.Second, time.Second})
+
+	stats := limiter.Stats()
+	this.So(stats.Authenticated.Throttled, should.Equal, 2)
+	this.So(stats.Authenticated.Available, should.Equal, 0)
+	this.So(stats.Authenticated.Utilization, should.Equal, 1)
+	this.So(stats.Public.Utilization, should.Equal, 0)
+}
+
+func (this *RateLimitFixture) TestCancelledWaitReturnsTokens() {
+	limiter := this.limiter(RateLimitBlock, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true), should.BeNil)
+	this.So(limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true), should.BeNil)
+	cancel()
+
+	err := limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true)
+	this.So(errors.Is(err, context.Canceled), should.BeTrue)
+	this.So(limiter.Stats().Authenticated.Available, should.Equal, 1)
+}
+
+func (this *RateLimitFixture) TestUtilization() {
+	limiter := this.limiter(RateLimitFailFast, nil)
+	limiter.Acquire(context.Background(), "GET", "https://api.bittrex.com/v3/markets", false)
+	limiter.Acquire(context.Background(), "GET", "https://api.bittrex.com/v3/markets", false)
+	limiter.Acquire(context.Background(), "GET", "https://api.bittrex.com/v3/markets", false)
+
+	stats := limiter.Stats()
+	this.So(stats.Public.Capacity, should.Equal, 4)
+	this.So(stats.Public.Available, should.Equal, 1)
+	this.So(stats.Public.Utilization, should.Equal, 0.75)
+}
+
+func (this *RateLimitFixture) TestClientFailsFastWithoutSending() {
+	httpClient := &scriptedHttpClient{}
+	httpClient.respond(http.StatusOK, "[]", nil)
+	client := NewBittrexClient("key", "secret", httpClient)
+	limiter := this.limiter(RateLimitFailFast, map[string]int{"GET /markets": 4})
+	client.SetRateLimiter(limiter)
+	this.So(limiter.Acquire(context.Background(), "GET", "https://api.bittrex.com/v3/markets", false), should.BeNil)
+
+	_, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(errors.Is(err, ErrRateLimitExceeded), should.BeTrue)
+	this.So(httpClient.requests, should.BeEmpty)
+}
+
+func (this *RateLimitFixture) TestDefaultConfigStaysWithinQuota() {
+	config := DefaultRateLimitConfig()
+	config.Mode = RateLimitFailFast
+	limiter := NewTokenBucketLimiter(config)
+	limiter.now = func() time.Time { return this.now }
+	limiter.public.updated = this.now
+	limiter.authenticated.updated = this.now
+
+	allowed := 0
+	ctx := context.Background()
+	for end := this.now.Add(time.Minute); this.now.Before(end); this.now = this.now.Add(100 * time.Millisecond) {
+		for limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/markets", false) == nil {
+			allowed++
+		}
+		for limiter.Acquire(ctx, "GET", "https://api.bittrex.com/v3/balances", true) == nil {
+			allowed++
+		}
+	}
+	this.So(allowed, should.BeLessThanOrEqualTo, requestsPerMinute)
+	this.So(allowed, should.BeGreaterThan, requestsPerMinute-5)
+}
+
+func (this *RateLimitFixture) TestDisabledBucket() {
+	limiter := NewTokenBucketLimiter(RateLimitConfig{Mode: RateLimitFailFast})
+	for i := 0; i < 100; i++ {
+		this.So(limiter.Acquire(context.Background(), "GET", "https://api.bittrex.com/v3/markets", false), should.BeNil)
+	}
+}
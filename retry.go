@@ -0,0 +1,168 @@
+package bittrex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+//Retries network errors, 429 and 5xx responses with jittered exponential backoff. GET and DELETE requests are
+//retried freely, POST /orders only when the order has a clientOrderId and no order with that id exists yet, other
+//POST requests never.
+type RetryPolicy struct {
+	MaxAttempts int           //Including the first one, retries are disabled below 2
+	BaseDelay   time.Duration //Delay before the first retry, doubled for each following one
+	MaxDelay    time.Duration //Upper bound of the backoff, a longer Retry-After ends the retries
+}
+
+//Returned when an order failed with a retryable error and looking it up by clientOrderId failed as well, so it is
+//unknown whether the order was placed. errors.Is and errors.As see CheckErr.
+type OrderStateUnknownError struct {
+	ClientOrderID string
+	Err           error //Error of the order request
+	CheckErr      error //Error of the lookup
+}
+
+func (this *OrderStateUnknownError) Error() string {
+	return "bittrex: order " + this.ClientOrderID + " may have been placed after " + this.Err.Error() + ", looking it up failed: " + this.CheckErr.Error()
+}
+
+func (this *OrderStateUnknownError) Unwrap() error {
+	return this.CheckErr
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 4, BaseDelay: 250 * time.Millisecond, MaxDelay: 10 * time.Second}
+}
+
+func (this *bittrexClient) SetRetryPolicy(policy RetryPolicy) {
+	this.retry = policy
+}
+
+//Waits for Retry-After when the response carries one, otherwise for a random delay between half and all of the
+//exponential backoff. Returns false when Retry-After exceeds MaxDelay.
+func (this RetryPolicy) delay(attempt int, header http.Header) (time.Duration, bool) {
+	if retryAfter, ok := parseRetryAfter(header); ok {
+		return retryAfter, retryAfter <= this.MaxDelay
+	}
+
+	backoff := this.BaseDelay
+	for i := 1; i < attempt && backoff < this.MaxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > this.MaxDelay {
+		backoff = this.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1)), true
+}
+
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode == http.StatusTooManyRequests || apiError.ServerError()
+	}
+	var nonJSON *NonJSONResponseError
+	if errors.As(err, &nonJSON) {
+		return nonJSON.StatusCode == http.StatusTooManyRequests || nonJSON.ServerError()
+	}
+
+	//Every transport error is a *url.Error, which is a net.Error, so only timeouts and dropped connections count
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+//Reports whether the request may be sent again and, for POST /orders, the clientOrderId to look up first
+func idempotentRequest(method string, uri string, payload string) (string, bool) {
+	switch method {
+	case "GET", "DELETE":
+		return "", true
+	case "POST":
+		if !strings.HasSuffix(uri, "/orders") {
+			return "", false
+		}
+		var order struct {
+			ClientOrderID string `json:"clientOrderId"`
+		}
+		if err := json.Unmarshal([]byte(payload), &order); err != nil || order.ClientOrderID == "" {
+			return "", false
+		}
+		return order.ClientOrderID, true
+	}
+	return "", false
+}
+
+//Looks for an order created by a previous attempt among the open and the most recent closed orders
+func (this *bittrexClient) findOrderByClientID(ctx context.Context, baseURI string, clientOrderID string) ([]byte, bool, error) {
+	for _, uri := range []string{baseURI + "/orders/open", baseURI + "/orders/closed"} {
+		body, _, err := this.send(ctx, "GET", uri, "", true)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var orders []json.RawMessage
+		if err := json.Unmarshal(body, &orders); err != nil {
+			return nil, false, err
+		}
+		for _, raw := range orders {
+			var order struct {
+				ClientOrderID string `json:"clientOrderId"`
+			}
+			if err := json.Unmarshal(raw, &order); err != nil {
+				return nil, false, err
+			}
+			if order.ClientOrderID == clientOrderID {
+				return raw, true, nil
+			}
+		}
+	}
+	return nil, false, nil
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,240 @@
+package bittrex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestRetryFixture(t *testing.T) {
+	gunit.Run(new(RetryFixture), t)
+}
+
+type RetryFixture struct {
+	*gunit.Fixture
+	http   *scriptedHttpClient
+	client *bittrexClient
+	sleeps []time.Duration
+}
+
+func (this *RetryFixture) Setup() {
+	this.http = &scriptedHttpClient{}
+	this.client = NewBittrexClient("key", "secret", this.http)
+	this.client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 5 * time.Second})
+	this.sleeps = nil
+	this.client.sleep = func(ctx context.Context, delay time.Duration) error {
+		this.sleeps = append(this.sleeps, delay)
+		return ctx.Err()
+	}
+}
+
+func (this *RetryFixture) TestRetriesServerError() {
+	this.http.respond(http.StatusServiceUnavailable, "{\"code\":\"THROTTLED\"}", nil)
+	this.http.respond(http.StatusOK, "{\"symbol\":\"ETH-BTC\"}", nil)
+	body, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets/ETH-BTC", "", false)
+	this.So(err, should.BeNil)
+	this.So(string(body), should.Equal, "{\"symbol\":\"ETH-BTC\"}")
+	this.So(this.http.requests, should.Resemble, []string{"GET /v3/markets/ETH-BTC", "GET /v3/markets/ETH-BTC"})
+	this.So(len(this.sleeps), should.Equal, 1)
+	this.So(this.sleeps[0], should.BeBetweenOrEqual, 50*time.Millisecond, 100*time.Millisecond)
+}
+
+func (this *RetryFixture) TestRetriesNetworkError() {
+	this.http.fail(&url.Error{Op: "Get", URL: "https://api.bittrex.com/v3/markets", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}})
+	this.http.respond(http.StatusOK, "[]", nil)
+	body, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(err, should.BeNil)
+	this.So(string(body), should.Equal, "[]")
+	this.So(len(this.http.requests), should.Equal, 2)
+}
+
+func (this *RetryFixture) TestRetriesTimeout() {
+	this.http.fail(&url.Error{Op: "Get", URL: "https://api.bittrex.com/v3/markets", Err: timeoutError{}})
+	this.http.respond(http.StatusOK, "[]", nil)
+	_, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(err, should.BeNil)
+	this.So(len(this.http.requests), should.Equal, 2)
+}
+
+func (this *RetryFixture) TestDoesNotRetryPermanentTransportError() {
+	this.http.fail(&url.Error{Op: "Get", URL: "ftp2://api.bittrex.com/v3/markets", Err: errors.New("unsupported protocol scheme \"ftp2\"")})
+	_, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(err, should.NotBeNil)
+	this.So(len(this.http.requests), should.Equal, 1)
+	this.So(this.sleeps, should.BeEmpty)
+}
+
+func (this *RetryFixture) TestHonorsRetryAfter() {
+	this.http.respond(http.StatusTooManyRequests, "{\"code\":\"RATE_LIMIT_EXCEEDED\"}", http.Header{"Retry-After": {"2"}})
+	this.http.respond(http.StatusOK, "[]", nil)
+	_, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(err, should.BeNil)
+	this.So(this.sleeps, should.Resemble, []time.Duration{2 * time.Second})
+}
+
+func (this *RetryFixture) TestGivesUpWhenRetryAfterExceedsMaxDelay() {
+	this.http.respond(http.StatusTooManyRequests, "{\"code\":\"RATE_LIMIT_EXCEEDED\"}", http.Header{"Retry-After": {"60"}})
+	_, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(errors.Is(err, ErrRateLimitExceeded), should.BeTrue)
+	this.So(len(this.http.requests), should.Equal, 1)
+	this.So(this.sleeps, should.BeEmpty)
+}
+
+func (this *RetryFixture) TestStopsAfterMaxAttempts() {
+	for i := 0; i < 3; i++ {
+		this.http.respond(http.StatusInternalServerError, "{\"code\":\"SERVER_ERROR\"}", nil)
+	}
+	_, err := this.client.Do(context.Background(), "DELETE", "https://api.bittrex.com/v3/orders/fab677a0", "", true)
+	var apiError *APIError
+	this.So(errors.As(err, &apiError), should.BeTrue)
+	this.So(apiError.StatusCode, should.Equal, http.StatusInternalServerError)
+	this.So(len(this.http.requests), should.Equal, 3)
+	this.So(len(this.sleeps), should.Equal, 2)
+	this.So(this.sleeps[1], should.BeBetweenOrEqual, 100*time.Millisecond, 200*time.Millisecond)
+}
+
+func (this *RetryFixture) TestDoesNotRetryClientError() {
+	this.http.respond(http.StatusBadRequest, "{\"code\":\"MIN_TRADE_REQUIREMENT_NOT_MET\"}", nil)
+	_, err := this.client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(errors.Is(err, ErrMinTradeRequirementNotMet), should.BeTrue)
+	this.So(len(this.http.requests), should.Equal, 1)
+}
+
+func (this *RetryFixture) TestDoesNotRetryOtherPosts() {
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	_, err := this.client.Do(context.Background(), "POST", "https://api.bittrex.com/v3/withdrawals", "{\"currencySymbol\":\"BTC\"}", true)
+	this.So(err, should.NotBeNil)
+	this.So(len(this.http.requests), should.Equal, 1)
+}
+
+func (this *RetryFixture) TestDoesNotRetryOrderWithoutClientOrderID() {
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	_, err := this.client.Do(context.Background(), "POST", "https://api.bittrex.com/v3/orders", "{\"marketSymbol\":\"ETH-BTC\"}", true)
+	this.So(err, should.NotBeNil)
+	this.So(len(this.http.requests), should.Equal, 1)
+}
+
+func (this *RetryFixture) TestReturnsExistingOrderInsteadOfRetrying() {
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	this.http.respond(http.StatusOK, "[{\"id\":\"55eb2c82\",\"clientOrderId\":\"other\"},{\"id\":\"fab677a0\",\"clientOrderId\":\"c1\"}]", nil)
+	body, err := this.client.Do(context.Background(), "POST", "https://api.bittrex.com/v3/orders", "{\"marketSymbol\":\"ETH-BTC\",\"clientOrderId\":\"c1\"}", true)
+	this.So(err, should.BeNil)
+	this.So(string(body), should.Equal, "{\"id\":\"fab677a0\",\"clientOrderId\":\"c1\"}")
+	this.So(this.http.requests, should.Resemble, []string{"POST /v3/orders", "GET /v3/orders/open"})
+}
+
+func (this *RetryFixture) TestRetriesOrderWithClientOrderIDNotFound() {
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	this.http.respond(http.StatusOK, "[]", nil)
+	this.http.respond(http.StatusOK, "[{\"id\":\"55eb2c82\",\"clientOrderId\":\"other\"}]", nil)
+	this.http.respond(http.StatusCreated, "{\"id\":\"fab677a0\",\"clientOrderId\":\"c1\"}", nil)
+	body, err := this.client.Do(context.Background(), "POST", "https://api.bittrex.com/v3/orders", "{\"marketSymbol\":\"ETH-BTC\",\"clientOrderId\":\"c1\"}", true)
+	this.So(err, should.BeNil)
+	this.So(string(body), should.Equal, "{\"id\":\"fab677a0\",\"clientOrderId\":\"c1\"}")
+	this.So(this.http.requests, should.Resemble, []string{"POST /v3/orders", "GET /v3/orders/open", "GET /v3/orders/closed", "POST /v3/orders"})
+}
+
+func (this *RetryFixture) TestReportsFailedOrderLookup() {
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	this.http.respond(http.StatusUnauthorized, "{\"code\":\"INVALID_SIGNATURE\"}", nil)
+	_, err := this.client.Do(context.Background(), "POST", "https://api.bittrex.com/v3/orders", "{\"marketSymbol\":\"ETH-BTC\",\"clientOrderId\":\"c1\"}", true)
+	var unknown *OrderStateUnknownError
+	this.So(errors.As(err, &unknown), should.BeTrue)
+	this.So(unknown.ClientOrderID, should.Equal, "c1")
+	var orderError *APIError
+	this.So(errors.As(unknown.Err, &orderError), should.BeTrue)
+	this.So(orderError.StatusCode, should.Equal, http.StatusServiceUnavailable)
+	var checkError *APIError
+	this.So(errors.As(err, &checkError), should.BeTrue)
+	this.So(checkError.StatusCode, should.Equal, http.StatusUnauthorized)
+	this.So(this.http.requests, should.Resemble, []string{"POST /v3/orders", "GET /v3/orders/open"})
+}
+
+func (this *RetryFixture) TestStopsWhenCancelledWhileWaiting() {
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	this.client.sleep = func(ctx context.Context, delay time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+	_, err := this.client.Do(ctx, "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(errors.Is(err, context.Canceled), should.BeTrue)
+	this.So(len(this.http.requests), should.Equal, 1)
+}
+
+func (this *RetryFixture) TestDisabledByDefault() {
+	client := NewBittrexClient("key", "secret", this.http)
+	this.http.respond(http.StatusServiceUnavailable, "{}", nil)
+	_, err := client.Do(context.Background(), "GET", "https://api.bittrex.com/v3/markets", "", false)
+	this.So(err, should.NotBeNil)
+	this.So(len(this.http.requests), should.Equal, 1)
+}
+
+func (this *RetryFixture) TestDelayIsCapped() {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+	delay, ok := policy.delay(8, nil)
+	this.So(ok, should.BeTrue)
+	this.So(delay, should.BeBetweenOrEqual, 1500*time.Millisecond, 3*time.Second)
+}
+
+///////////////////////////////////////
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+type scriptedResponse struct {
+	response *http.Response
+	err      error
+}
+
+type scriptedHttpClient struct {
+	responses []scriptedResponse
+	requests  []string
+	last      *http.Request
+}
+
+func (this *scriptedHttpClient) respond(statusCode int, body string, header http.Header) {
+	this.responses = append(this.responses, scriptedResponse{response: &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}})
+}
+
+func (this *scriptedHttpClient) fail(err error) {
+	this.responses = append(this.responses, scriptedResponse{err: err})
+}
+
+func (this *scriptedHttpClient) Get(url string) (*http.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (this *scriptedHttpClient) Post(url, contentType string, body io.Reader) (*http.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (this *scriptedHttpClient) Do(req *http.Request) (*http.Response, error) {
+	this.requests = append(this.requests, req.Method+" "+req.URL.Path)
+	this.last = req
+	if len(this.responses) == 0 {
+		return nil, errors.New("no scripted response left")
+	}
+	next := this.responses[0]
+	this.responses = this.responses[1:]
+	return next.response, next.err
+}
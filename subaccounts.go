@@ -0,0 +1,233 @@
+package bittrex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type subaccountKey struct{}
+
+//Authenticated requests sent with the returned context act on behalf of the subaccount
+func ContextWithSubaccount(ctx context.Context, subaccountID string) context.Context {
+	return context.WithValue(ctx, subaccountKey{}, subaccountID)
+}
+
+//Subaccount the request acts for, empty for the master account. Client implementations send it as Api-Subaccount-Id.
+func SubaccountID(ctx context.Context) string {
+	subaccountID, _ := ctx.Value(subaccountKey{}).(string)
+	return subaccountID
+}
+
+type subaccountClient struct {
+	Client
+	subaccountID string
+}
+
+func (this subaccountClient) Do(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, error) {
+	return this.Client.Do(ContextWithSubaccount(ctx, this.subaccountID), method, uri, payload, authenticate)
+}
+
+func (this subaccountClient) DoWithHeader(ctx context.Context, method, uri, payload string, authenticate bool) ([]byte, http.Header, error) {
+	return this.Client.DoWithHeader(ContextWithSubaccount(ctx, this.subaccountID), method, uri, payload, authenticate)
+}
+
+//Copy of the API acting for the subaccount, the original keeps acting for the account it was created for
+func (this *BittrexAPI) WithSubaccount(subaccountID string) *BittrexAPI {
+	client := this.client
+	if scoped, ok := client.(subaccountClient); ok {
+		client = scoped.Client
+	}
+
+	api := *this
+	api.client = subaccountClient{Client: client, subaccountID: subaccountID}
+	return &api
+}
+
+type Subaccount struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type NewTransfer struct {
+	ToSubaccountID  string          `json:"toSubaccountId,omitempty"` //Required unless ToMasterAccount
+	RequestID       string          `json:"requestId,omitempty"`
+	CurrencySymbol  string          `json:"currencySymbol"` //Required
+	Amount          decimal.Decimal `json:"amount"`         //Required
+	ToMasterAccount bool            `json:"toMasterAccount,omitempty"`
+}
+
+type Transfer struct {
+	ID                string          `json:"id"`
+	ToSubaccountID    string          `json:"toSubaccountId"`
+	ToMasterAccount   bool            `json:"toMasterAccount"`
+	FromSubaccountID  string          `json:"fromSubaccountId"`
+	FromMasterAccount bool            `json:"fromMasterAccount"`
+	RequestID         string          `json:"requestId"`
+	CurrencySymbol    string          `json:"currencySymbol"`
+	Amount            decimal.Decimal `json:"amount"`
+	ExecutedAt        time.Time       `json:"executedAt"`
+}
+
+//SubaccountID and MasterAccount select the other party, the recipient of sent transfers or the sender of received ones
+type TransferFilter struct {
+	SubaccountID   string
+	MasterAccount  bool
+	CurrencySymbol string
+	PageFilter
+}
+
+//party is "to" for sent transfers, "from" for received ones
+func (this TransferFilter) query(party string) url.Values {
+	query := url.Values{}
+	if this.SubaccountID != "" {
+		query.Set(party+"SubaccountId", this.SubaccountID)
+	}
+	if this.MasterAccount {
+		query.Set(party+"MasterAccount", "true")
+	}
+	if this.CurrencySymbol != "" {
+		query.Set("currencySymbol", this.CurrencySymbol)
+	}
+	this.PageFilter.encode(query)
+	return query
+}
+
+//Only NextPageToken, PreviousPageToken and PageSize apply
+func (this *BittrexAPI) GetSubaccounts(filter PageFilter) ([]Subaccount, error) {
+	return this.GetSubaccountsCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetSubaccountsCtx(ctx context.Context, filter PageFilter) ([]Subaccount, error) {
+	query := url.Values{}
+	filter.encode(query)
+
+	uri := this.uri + "/subaccounts" + encodeQuery(query)
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var subaccounts []Subaccount
+	if err := json.Unmarshal(body, &subaccounts); err != nil {
+		return nil, err
+	}
+
+	return subaccounts, nil
+}
+
+func (this *BittrexAPI) GetSubaccount(subaccountID string) (Subaccount, error) {
+	return this.GetSubaccountCtx(context.Background(), subaccountID)
+}
+
+func (this *BittrexAPI) GetSubaccountCtx(ctx context.Context, subaccountID string) (Subaccount, error) {
+	uri := this.uri + "/subaccounts/" + subaccountID
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return Subaccount{}, err
+	}
+
+	subaccount := Subaccount{}
+	if err := json.Unmarshal(body, &subaccount); err != nil {
+		return Subaccount{}, err
+	}
+
+	return subaccount, nil
+}
+
+func (this *BittrexAPI) CreateSubaccount() (Subaccount, error) {
+	return this.CreateSubaccountCtx(context.Background())
+}
+
+func (this *BittrexAPI) CreateSubaccountCtx(ctx context.Context) (Subaccount, error) {
+	uri := this.uri + "/subaccounts"
+	body, err := this.client.Do(ctx, "POST", uri, "{}", true)
+	if err != nil {
+		return Subaccount{}, err
+	}
+
+	subaccount := Subaccount{}
+	if err := json.Unmarshal(body, &subaccount); err != nil {
+		return Subaccount{}, err
+	}
+
+	return subaccount, nil
+}
+
+//Required currencySymbol, amount and either toSubaccountId or toMasterAccount
+func (this *BittrexAPI) CreateTransfer(transfer NewTransfer) (Transfer, error) {
+	return this.CreateTransferCtx(context.Background(), transfer)
+}
+
+func (this *BittrexAPI) CreateTransferCtx(ctx context.Context, transfer NewTransfer) (Transfer, error) {
+	payload, err := json.Marshal(transfer)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	uri := this.uri + "/transfers"
+	body, err := this.client.Do(ctx, "POST", uri, string(payload), true)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	returnTransfer := Transfer{}
+	if err := json.Unmarshal(body, &returnTransfer); err != nil {
+		return Transfer{}, err
+	}
+
+	return returnTransfer, nil
+}
+
+func (this *BittrexAPI) GetTransfer(transferID string) (Transfer, error) {
+	return this.GetTransferCtx(context.Background(), transferID)
+}
+
+func (this *BittrexAPI) GetTransferCtx(ctx context.Context, transferID string) (Transfer, error) {
+	uri := this.uri + "/transfers/" + transferID
+	body, err := this.client.Do(ctx, "GET", uri, "", true)
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	transfer := Transfer{}
+	if err := json.Unmarshal(body, &transfer); err != nil {
+		return Transfer{}, err
+	}
+
+	return transfer, nil
+}
+
+func (this *BittrexAPI) GetSentTransfers(filter TransferFilter) ([]Transfer, error) {
+	return this.GetSentTransfersCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetSentTransfersCtx(ctx context.Context, filter TransferFilter) ([]Transfer, error) {
+	return this.getTransfers(ctx, "/transfers/sent"+encodeQuery(filter.query("to")))
+}
+
+func (this *BittrexAPI) GetReceivedTransfers(filter TransferFilter) ([]Transfer, error) {
+	return this.GetReceivedTransfersCtx(context.Background(), filter)
+}
+
+func (this *BittrexAPI) GetReceivedTransfersCtx(ctx context.Context, filter TransferFilter) ([]Transfer, error) {
+	return this.getTransfers(ctx, "/transfers/received"+encodeQuery(filter.query("from")))
+}
+
+func (this *BittrexAPI) getTransfers(ctx context.Context, path string) ([]Transfer, error) {
+	body, err := this.client.Do(ctx, "GET", this.uri+path, "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers []Transfer
+	if err := json.Unmarshal(body, &transfers); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
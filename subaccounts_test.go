@@ -0,0 +1,106 @@
+package bittrex
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/smartystreets/assertions/should"
+	"github.com/smartystreets/gunit"
+)
+
+func TestSubaccountsFixture(t *testing.T) {
+	gunit.Run(new(SubaccountsFixture), t)
+}
+
+type SubaccountsFixture struct {
+	*gunit.Fixture
+}
+
+func (this *SubaccountsFixture) TestGetSubaccounts() {
+	client := &recordingClient{responses: map[string]string{
+		"/subaccounts?pageSize=2": "[{\"id\": \"6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51\",\"createdAt\": \"2020-09-01T10:00:00Z\"}]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.GetSubaccounts(PageFilter{PageSize: 2})
+	this.So(err, should.BeNil)
+	this.So(result, should.Resemble, []Subaccount{
+		{ID: "6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51", CreatedAt: time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)},
+	})
+}
+
+func (this *SubaccountsFixture) TestCreateSubaccount() {
+	client := &recordingClient{responses: map[string]string{
+		"/subaccounts": "{\"id\": \"6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51\",\"createdAt\": \"2020-09-01T10:00:00Z\"}",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.CreateSubaccount()
+	this.So(err, should.BeNil)
+	this.So(result.ID, should.Equal, "6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51")
+	this.So(client.payloads, should.Resemble, []string{"{}"})
+}
+
+func (this *SubaccountsFixture) TestCreateTransfer() {
+	client := &recordingClient{responses: map[string]string{
+		"/transfers": "{\"id\": \"0f3a4a6e-0e3b-4d39-9a4e-4c6d2a5b7e10\",\"toSubaccountId\": \"6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51\",\"requestId\": \"r1\",\"currencySymbol\": \"BTC\",\"amount\": \"0.5\"}",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	result, err := bittrex.CreateTransfer(NewTransfer{
+		ToSubaccountID: "6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51",
+		RequestID:      "r1",
+		CurrencySymbol: "BTC",
+		Amount:         decimal.RequireFromString("0.5"),
+	})
+	this.So(err, should.BeNil)
+	this.So(result.ID, should.Equal, "0f3a4a6e-0e3b-4d39-9a4e-4c6d2a5b7e10")
+	this.So(result.Amount.String(), should.Equal, "0.5")
+	this.So(client.payloads, should.Resemble, []string{
+		"{\"toSubaccountId\":\"6c6dcbb0-1a93-4c2e-8b6a-3f8c0d7e6a51\",\"requestId\":\"r1\",\"currencySymbol\":\"BTC\",\"amount\":\"0.5\"}",
+	})
+}
+
+func (this *SubaccountsFixture) TestTransferHistory() {
+	client := &recordingClient{responses: map[string]string{
+		"/transfers/sent?currencySymbol=BTC&toSubaccountId=6c6dcbb0": "[{\"id\": \"t1\",\"toSubaccountId\": \"6c6dcbb0\",\"currencySymbol\": \"BTC\",\"amount\": \"0.5\",\"executedAt\": \"2020-09-01T10:00:00Z\"}]",
+		"/transfers/received?fromMasterAccount=true":                 "[{\"id\": \"t2\",\"fromMasterAccount\": true,\"currencySymbol\": \"ETH\",\"amount\": \"2\",\"executedAt\": \"2020-09-02T10:00:00Z\"}]",
+	}}
+	bittrex := NewBittrexAPI(client, "")
+	sent, err := bittrex.GetSentTransfers(TransferFilter{SubaccountID: "6c6dcbb0", CurrencySymbol: "BTC"})
+	this.So(err, should.BeNil)
+	this.So(len(sent), should.Equal, 1)
+	this.So(sent[0].ToSubaccountID, should.Equal, "6c6dcbb0")
+
+	received, err := bittrex.GetReceivedTransfers(TransferFilter{MasterAccount: true})
+	this.So(err, should.BeNil)
+	this.So(len(received), should.Equal, 1)
+	this.So(received[0].FromMasterAccount, should.BeTrue)
+	this.So(received[0].ExecutedAt, should.Equal, time.Date(2020, 9, 2, 10, 0, 0, 0, time.UTC))
+}
+
+func (this *SubaccountsFixture) TestWithSubaccountScopesRequests() {
+	client := &recordingClient{responses: map[string]string{"/balances": "[]"}}
+	master := NewBittrexAPI(client, "")
+	first := master.WithSubaccount("sub-1")
+	second := first.WithSubaccount("sub-2")
+
+	master.GetBalances()
+	first.GetBalances()
+	second.GetBalances()
+	this.So(SubaccountID(client.contexts[0]), should.BeEmpty)
+	this.So(SubaccountID(client.contexts[1]), should.Equal, "sub-1")
+	this.So(SubaccountID(client.contexts[2]), should.Equal, "sub-2")
+}
+
+func (this *SubaccountsFixture) TestSignsSubaccountHeader() {
+	httpClient := &scriptedHttpClient{}
+	httpClient.respond(http.StatusOK, "[]", nil)
+	client := NewBittrexClient("key", "secret", httpClient)
+	client.now = func() time.Time { return time.Unix(0, 1601553600123*int64(time.Millisecond)) }
+	bittrex := NewBittrexAPI(client, "https://api.bittrex.com/v3").WithSubaccount("sub-1")
+
+	_, err := bittrex.GetBalances()
+	this.So(err, should.BeNil)
+	this.So(httpClient.last.Header.Get("Api-Subaccount-Id"), should.Equal, "sub-1")
+	this.So(httpClient.last.Header.Get("Api-Signature"), should.Equal, "a48df8e76bdbe4e349dd865f7890f2f7fe6e02f9e569df4e2b0d2fd31796737933b9cefb71f40b701333dfeaef73932f9e686b02219fb5269d1e1b4790117e14")
+}